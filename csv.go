@@ -11,19 +11,45 @@ import (
 	"encoding/csv"
 	"io"
 	"os"
+	"strings"
+)
+
+const UTF8_BOM = "\ufeff"
+
+const (
+	EXPORT_NEWLINE_DEFAULT = iota
+	EXPORT_NEWLINE_PRESERVE
+	EXPORT_NEWLINE_SPACE
 )
 
 // Start A new table by importing from a CSV file
 // Takes io.Writer and csv File name
 func NewCSV(writer io.Writer, fileName string, hasHeader bool) (*Table, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return &Table{}, err
+	}
+	defer file.Close()
+	return NewCSVFromReader(writer, file, hasHeader)
+}
+
+// Start A new table by importing from a delimited file
+// Takes the field delimiter, such as ';' or '\t'
+func NewCSVWithDelimiter(writer io.Writer, fileName string, hasHeader bool, delimiter rune) (*Table, error) {
 	file, err := os.Open(fileName)
 	if err != nil {
 		return &Table{}, err
 	}
 	defer file.Close()
 	csvReader := csv.NewReader(file)
-	t, err := NewCSVReader(writer, csvReader, hasHeader)
-	return t, err
+	csvReader.Comma = delimiter
+	return NewCSVReader(writer, csvReader, hasHeader)
+}
+
+// Start A new table by importing CSV data from any io.Reader
+// Useful when the data is already in memory or comes over the network
+func NewCSVFromReader(writer io.Writer, reader io.Reader, hasHeader bool) (*Table, error) {
+	return NewCSVReader(writer, csv.NewReader(reader), hasHeader)
 }
 
 //  Start a New Table Writer with csv.Reader
@@ -50,3 +76,191 @@ func NewCSVReader(writer io.Writer, csvReader *csv.Reader, hasHeader bool) (*Tab
 	}
 	return t, nil
 }
+
+// Options for RenderCSVStream
+type CSVOptions struct {
+	Comma      rune  // Field delimiter, ',' when zero
+	HasHeader  bool  // Use the first record as the table header
+	SampleRows int   // Rows read ahead to size the columns, 100 when zero
+	Widths     []int // Fixed column widths, used instead of sampling
+}
+
+// Render a CSV stream as a table while it is read
+// Column widths come from the first SampleRows records, or from Widths
+// when set, and are then frozen so every later row is printed as soon as
+// it is read. Cells wider than their column are wrapped, or truncated
+// when a word does not fit.
+// Reading stops at the first failed write and its error is returned
+func RenderCSVStream(writer io.Writer, reader io.Reader, opts CSVOptions) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	if opts.Comma != 0 {
+		csvReader.Comma = opts.Comma
+	}
+	if opts.SampleRows <= 0 {
+		opts.SampleRows = 100
+	}
+	return NewWriter(writer).renderCSVStream(csvReader, opts)
+}
+
+// Render records of a CSV reader as they are read
+// Only the sampled rows are kept, every later row is dropped once printed
+func (t *Table) renderCSVStream(csvReader *csv.Reader, opts CSVOptions) error {
+	w := &errWriter{w: t.out}
+	t.out = w
+
+	if opts.HasHeader {
+		headers, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		t.SetHeader(headers)
+	}
+
+	fixed := len(opts.Widths) > 0
+	if fixed {
+		t.cs = make(map[int]int)
+		for i, width := range opts.Widths {
+			t.cs[i] = width
+		}
+	}
+
+	// Read ahead to size the columns
+	eof := false
+	for i := 0; i < opts.SampleRows; i++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			eof = true
+			break
+		} else if err != nil {
+			return err
+		}
+		if fixed {
+			t.appendFitted(record)
+		} else {
+			t.Append(record)
+		}
+	}
+
+	// An empty stream without a header has no table to draw
+	if eof && len(t.headers) == 0 && len(t.lines) == 0 {
+		return nil
+	}
+
+	// Ragged records and fixed widths may add columns the header lacks
+	if len(t.headers) > 0 {
+		for len(t.headers) < len(t.cs) {
+			t.headers = append(t.headers, "")
+		}
+	}
+
+	if t.borders.Top {
+		t.printLine(ruleTop, true)
+	}
+	t.printHeading()
+	t.printRows()
+
+	for n := len(t.lines); !eof && w.err == nil; n++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		t.rows = t.rows[:0]
+		t.lines = t.lines[:0]
+		t.rs = make(map[int]int)
+		t.appendFitted(record)
+		if t.rowLine {
+			t.printLine(ruleMid, true)
+		}
+		t.printRow(t.lines[0], 0, n)
+	}
+
+	if t.borders.Bottom {
+		t.printLine(ruleBottom, true)
+	}
+	return w.err
+}
+
+// Append a row fitted into the current column widths
+// Missing cells are left empty and extra cells are dropped
+func (t *Table) appendFitted(row []string) {
+	n := len(t.lines)
+	line := [][]string{}
+	for i := 0; i < len(t.cs); i++ {
+		str := ""
+		if i < len(row) {
+			str = row[i]
+		}
+		width := t.cs[i]
+
+		// Cells with words wider than the column are truncated, not wrapped
+		raw := getLines(str)
+		if t.autoWrap {
+			if wrapped, used := WrapString(str, width); used <= width {
+				raw = wrapped
+			}
+		}
+		for j, l := range raw {
+			raw[j] = Truncate(l, width, t.ellipsis)
+		}
+		if h := len(raw); h > t.rs[n] {
+			t.rs[n] = h
+		}
+		line = append(line, raw)
+	}
+	t.rows = append(t.rows, row)
+	t.lines = append(t.lines, line)
+}
+
+// Write the header and rows as CSV
+// Cells containing newlines are quoted as described in RFC 4180
+func (t *Table) WriteCSV(writer io.Writer) error {
+	if t.csvBOM {
+		if _, err := io.WriteString(writer, UTF8_BOM); err != nil {
+			return err
+		}
+	}
+	return t.writeDelimited(writer, ',', t.exportNL == EXPORT_NEWLINE_SPACE)
+}
+
+// Write the header and rows as tab separated values
+// Newlines inside cells are replaced by a space unless preserved
+func (t *Table) WriteTSV(writer io.Writer) error {
+	return t.writeDelimited(writer, '\t', t.exportNL != EXPORT_NEWLINE_PRESERVE)
+}
+
+func (t *Table) writeDelimited(writer io.Writer, comma rune, join bool) error {
+	w := csv.NewWriter(writer)
+	w.Comma = comma
+
+	records := [][]string{}
+	if len(t.headers) > 0 {
+		records = append(records, t.headers)
+	}
+	records = append(records, t.rows...)
+
+	for _, record := range records {
+		if join {
+			record = joinNewlines(record)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// Replace newlines inside every cell with a single space
+func joinNewlines(record []string) []string {
+	out := make([]string, len(record))
+	for i, v := range record {
+		v = strings.Replace(v, "\r\n", nl, -1)
+		out[i] = strings.Replace(v, nl, sp, -1)
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"strings"
+)
+
+// CellChange describes a data cell whose rendered text differs
+// from a previous snapshot of the table
+type CellChange struct {
+	Row  int    // Row index in append order
+	Col  int    // Column index, -1 for label, index and hash columns
+	Text string // Padded cell text, one line per rendered line
+	X    int    // Screen column of the first cell character
+	Y    int    // Screen line of the first cell line
+}
+
+// Return the data cells that differ from a previous snapshot
+// Both tables are expected to share the same dimensions, so redrawing
+// only the changed cells updates the screen to this table. Accessible
+// output and tables split into column blocks report no cells
+func (t *Table) DiffRender(prev *Table) []CellChange {
+	old := make(map[cellKey]string)
+	for _, c := range prev.cellTexts() {
+		old[cellKey{c.Row, c.pos}] = c.Text
+	}
+	changes := []CellChange{}
+	for _, c := range t.cellTexts() {
+		if text, ok := old[cellKey{c.Row, c.pos}]; ok && text == c.Text {
+			continue
+		}
+		changes = append(changes, c.CellChange)
+	}
+	return changes
+}
+
+// A located cell and its column in the prepared table
+type placedCell struct {
+	CellChange
+	pos int
+}
+
+// Locate every data cell of the rendered rows, in display order
+// Rows are found by their line kind and columns by the separator widths,
+// so headers, borders and separators of any width are accounted for
+func (t Table) cellTexts() []placedCell {
+	p := t.prepared()
+	if p.style == StyleAccessible || (p.maxCols > 0 && len(p.cs) > p.maxCols) {
+		return nil
+	}
+
+	// Screen lines of the data rows, in display order
+	ys := []int{}
+	texts := [][]rune{}
+	for y, line := range t.RenderLines() {
+		if line.Kind == LineRow {
+			text := ansi.ReplaceAllLiteralString(line.Text, "")
+			ys = append(ys, y)
+			texts = append(texts, gridRow(text, DisplayWidth(text)))
+		}
+	}
+
+	// Screen column where each cell starts
+	xs := make([]int, len(p.cs))
+	x := 0
+	for col := range xs {
+		sep := p.pColumn
+		if (!p.borders.Left && col == 0) || (!p.interior && col > 0) {
+			sep = SPACE
+		}
+		x += DisplayWidth(sep) + 1
+		xs[col] = x
+		x += p.cs[col] + 1
+	}
+
+	order := p.rowOrder()
+	if p.maxRows > 0 && len(order) > p.maxRows {
+		order = order[:p.maxRows]
+	}
+	cols := t.preparedColumns()
+	cells := []placedCell{}
+	k := 0
+	for _, n := range order {
+		for col := 0; col < len(p.cs); col++ {
+			lines := []string{}
+			for h := 0; h < p.rs[n] && k+h < len(ys); h++ {
+				lines = append(lines, gridText(texts[k+h], xs[col], p.cs[col]))
+			}
+			c := placedCell{CellChange{Row: n, Col: -1, Text: strings.Join(lines, nl), X: xs[col]}, col}
+			if k < len(ys) {
+				c.Y = ys[k]
+			}
+			if col < len(cols) {
+				c.Col = cols[col]
+			}
+			cells = append(cells, c)
+		}
+		k += p.rs[n]
+	}
+	return cells
+}
+
+// Return the user column shown by each column of the prepared table
+// Label, index and hash columns are -1
+func (t Table) preparedColumns() []int {
+	cols := []int{}
+	for i := 0; i < len(t.cs); i++ {
+		if !t.hidden[i] {
+			cols = append(cols, i)
+		}
+	}
+	if t.hashFn != nil {
+		cols = append(cols, -1)
+	}
+	if len(t.labels) > 0 {
+		cols = append([]int{-1}, cols...)
+	}
+	if t.autoIndex {
+		cols = append([]int{-1}, cols...)
+	}
+	if t.rtl {
+		for i, j := 0, len(cols)-1; i < j; i, j = i+1, j-1 {
+			cols[i], cols[j] = cols[j], cols[i]
+		}
+	}
+	return cols
+}
+
+// Read width cells of a grid line starting at x
+func gridText(line []rune, x, width int) string {
+	if x >= len(line) {
+		return ""
+	}
+	end := x + width
+	if end > len(line) {
+		end = len(line)
+	}
+	var b strings.Builder
+	for _, r := range line[x:end] {
+		if r != 0 {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"strings"
+)
+
+// Render the table as an HTML table
+// Every cell is escaped, the lines of a cell are joined with <br> and
+// aligned columns carry a text-align style. Cells are not wrapped, the
+// browser lays out the columns
+func (t *Table) RenderHTML(writer io.Writer) error {
+	p := t.exported()
+	cols := len(p.cs)
+	styles := make([]string, cols)
+	for i := range styles {
+		switch p.dataAlign(i) {
+		case ALIGN_LEFT:
+			styles[i] = ` style="text-align:left"`
+		case ALIGN_CENTER:
+			styles[i] = ` style="text-align:center"`
+		case ALIGN_RIGHT:
+			styles[i] = ` style="text-align:right"`
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<table>" + nl)
+	if len(p.headers) > 0 {
+		buf.WriteString("<thead>" + nl + "<tr>")
+		for _, h := range p.headers {
+			buf.WriteString("<th>" + htmlCell(getLines(p.formatHeader(h))) + "</th>")
+		}
+		buf.WriteString("</tr>" + nl + "</thead>" + nl)
+	}
+	buf.WriteString("<tbody>" + nl)
+	for _, n := range p.rowOrder() {
+		buf.WriteString("<tr>")
+		for i := 0; i < cols; i++ {
+			buf.WriteString("<td" + styles[i] + ">" + htmlCell(getLines(p.cellText(n, i))) + "</td>")
+		}
+		buf.WriteString("</tr>" + nl)
+	}
+	buf.WriteString("</tbody>" + nl)
+	if len(p.footers) > 0 {
+		buf.WriteString("<tfoot>" + nl + "<tr>")
+		for i, f := range p.footers {
+			buf.WriteString("<td" + styles[i] + ">" + htmlCell(getLines(p.footerText(f))) + "</td>")
+		}
+		buf.WriteString("</tr>" + nl + "</tfoot>" + nl)
+	}
+	buf.WriteString("</table>" + nl)
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// Escape the lines of a cell and join them with <br>
+func htmlCell(lines []string) string {
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = html.EscapeString(strings.TrimSpace(line))
+	}
+	return strings.Join(escaped, "<br>")
+}
@@ -0,0 +1,77 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"strings"
+)
+
+type LineKind int
+
+const (
+	LineTopRule LineKind = iota
+	LineHeader
+	LineHeaderRule
+	LineRow
+	LineRowRule
+	LineFooterRule
+	LineFooter
+	LineBottomRule
+	LineCaption
+)
+
+// A rendered line and the part of the table it belongs to
+type RenderedLine struct {
+	Kind LineKind
+	Text string
+}
+
+// Render the table as a list of lines tagged with their kind
+// Callers can route or style each part of the table themselves.
+// Text holds the line without its newline
+func (t *Table) RenderLines() []RenderedLine {
+	sink := &lineSink{nl: t.newLine}
+	c := *t
+	c.out = sink
+	c.render()
+	if sink.pending.Len() > 0 {
+		sink.lines = append(sink.lines, RenderedLine{sink.kind, sink.pending.String()})
+	}
+	return sink.lines
+}
+
+// lineSink splits written output into lines of the current kind
+type lineSink struct {
+	nl      string
+	kind    LineKind
+	pending strings.Builder
+	lines   []RenderedLine
+}
+
+func (s *lineSink) Write(p []byte) (int, error) {
+	s.pending.Write(p)
+	text := s.pending.String()
+	for {
+		i := strings.Index(text, s.nl)
+		if i < 0 {
+			break
+		}
+		s.lines = append(s.lines, RenderedLine{s.kind, text[:i]})
+		text = text[i+len(s.nl):]
+	}
+	s.pending.Reset()
+	s.pending.WriteString(text)
+	return len(p), nil
+}
+
+// Set the kind of the lines written next when rendering lines
+func (t Table) mark(kind LineKind) {
+	if s, ok := t.out.(*lineSink); ok {
+		s.kind = kind
+	}
+}
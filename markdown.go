@@ -0,0 +1,113 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// Render the table as GitHub flavored Markdown
+// Pipes are escaped, multi-line cells are joined with <br> and the
+// separator row carries a colon for every aligned column. Markdown has
+// no footer, so footers are rendered as a last data row
+func (t *Table) RenderMarkdown(writer io.Writer) error {
+	p := t.exported()
+	cols := len(p.cs)
+
+	header := make([]string, cols)
+	for i := range header {
+		if i < len(p.headers) {
+			header[i] = markdownCell(p.formatHeader(p.headers[i]))
+		}
+	}
+	rows := [][]string{}
+	for _, n := range p.rowOrder() {
+		row := make([]string, cols)
+		for i := range row {
+			row[i] = p.cellText(n, i)
+		}
+		rows = append(rows, markdownRow(row, cols))
+	}
+	if len(p.footers) > 0 {
+		footer := make([]string, len(p.footers))
+		for i, f := range p.footers {
+			footer[i] = p.footerText(f)
+		}
+		rows = append(rows, markdownRow(footer, cols))
+	}
+
+	widths := make([]int, cols)
+	for i := range widths {
+		widths[i] = 3
+		for _, row := range append([][]string{header}, rows...) {
+			if w := DisplayWidth(row[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	aligns := make([]int, cols)
+	rule := make([]string, cols)
+	for i := range rule {
+		aligns[i] = p.dataAlign(i)
+		rule[i] = markdownRule(aligns[i], widths[i])
+	}
+	writeMarkdownRow(&buf, header, widths, aligns)
+	buf.WriteString("| " + strings.Join(rule, " | ") + " |" + nl)
+	for _, row := range rows {
+		writeMarkdownRow(&buf, row, widths, aligns)
+	}
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// Escape cells of a row, padding it to cols cells
+func markdownRow(row []string, cols int) []string {
+	out := make([]string, cols)
+	for i := range out {
+		if i < len(row) {
+			out[i] = markdownCell(row[i])
+		}
+	}
+	return out
+}
+
+// Escape pipes and join the lines of a cell with <br>
+func markdownCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	s = strings.Replace(s, "\r\n", nl, -1)
+	return strings.Join(strings.Split(strings.TrimSpace(s), nl), "<br>")
+}
+
+// Return the separator of a column with its alignment colons
+func markdownRule(align, width int) string {
+	switch align {
+	case ALIGN_LEFT:
+		return ":" + strings.Repeat(ROW, width-1)
+	case ALIGN_RIGHT:
+		return strings.Repeat(ROW, width-1) + ":"
+	case ALIGN_CENTER:
+		return ":" + strings.Repeat(ROW, width-2) + ":"
+	}
+	return strings.Repeat(ROW, width)
+}
+
+func writeMarkdownRow(buf *bytes.Buffer, row []string, widths, aligns []int) {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		if aligns[i] == ALIGN_RIGHT {
+			cells[i] = PadLeft(v, SPACE, widths[i])
+		} else {
+			cells[i] = PadRight(v, SPACE, widths[i])
+		}
+	}
+	buf.WriteString("| " + strings.Join(cells, " | ") + " |" + nl)
+}
@@ -0,0 +1,129 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TIME_LAYOUT = "2006-01-02"
+)
+
+type ColumnType int
+
+const (
+	ColumnString ColumnType = iota
+	ColumnNumber
+	ColumnDate
+	ColumnDecimal
+)
+
+var numberReplacer = strings.NewReplacer(",", "", "$", "", "€", "", "£", "", "¥", "", "%", "")
+
+// Set Column Type
+// Typed columns sort and align by their type instead of their text.
+// Decimal columns sort as numbers and line up their decimal points
+func (t *Table) SetColumnType(col int, typ ColumnType) {
+	t.colTypes[col] = typ
+}
+
+// Set the time layout used to parse ColumnDate cells
+// Default is "2006-01-02"
+func (t *Table) SetTimeLayout(layout string) {
+	t.timeLayout = layout
+}
+
+// Sort rows by a column when rendering
+// Rows with equal values keep their append order
+func (t *Table) SortByColumn(col int, desc bool) {
+	t.sortCol = col
+	t.sortDesc = desc
+}
+
+// Return row indexes in the order they are rendered
+// Rows rejected by the row filter are left out
+func (t Table) rowOrder() []int {
+	order := make([]int, 0, len(t.lines))
+	src := t.sourceRows()
+	for i := range t.lines {
+		if t.filter == nil || i >= len(src) || t.filter(src[i]) {
+			order = append(order, i)
+		}
+	}
+	if t.sortCol < 0 {
+		return order
+	}
+
+	typ := t.colTypes[t.sortCol]
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := t.rawCell(order[a], t.sortCol), t.rawCell(order[b], t.sortCol)
+		if t.sortDesc {
+			x, y = y, x
+		}
+		return t.less(x, y, typ)
+	})
+	return order
+}
+
+// Return the append index of a rendered row
+// Returns -1 when the display row is out of range
+func (t Table) OriginalRowIndex(displayRow int) int {
+	order := t.rowOrder()
+	if displayRow < 0 || displayRow >= len(order) {
+		return -1
+	}
+	return order[displayRow]
+}
+
+// Return the appended text of a cell or an empty string
+func (t Table) rawCell(row, col int) string {
+	if row < len(t.rows) && col < len(t.rows[row]) {
+		return t.rows[row][col]
+	}
+	return ""
+}
+
+// Compare two cells by column type
+// Values that fail to parse sort after values that parse
+func (t Table) less(a, b string, typ ColumnType) bool {
+	switch typ {
+	case ColumnNumber, ColumnDecimal:
+		x, errX := parseNumber(a)
+		y, errY := parseNumber(b)
+		if errX == nil && errY == nil {
+			return x < y
+		}
+		if errX == nil || errY == nil {
+			return errX == nil
+		}
+	case ColumnDate:
+		x, errX := time.Parse(t.timeLayout, strings.TrimSpace(a))
+		y, errY := time.Parse(t.timeLayout, strings.TrimSpace(b))
+		if errX == nil && errY == nil {
+			return x.Before(y)
+		}
+		if errX == nil || errY == nil {
+			return errX == nil
+		}
+	}
+	return a < b
+}
+
+// Parse a number ignoring grouping, currency and percent signs
+// Accounting style parentheses are read as a negative sign
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if parens.MatchString(s) {
+		s = "-" + s[1:len(s)-1]
+	}
+	return strconv.ParseFloat(numberReplacer.Replace(s), 64)
+}
@@ -0,0 +1,103 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Append a row from the exported fields of a struct or struct pointer
+// Without a header, the header is derived from the fields. Column names come
+// from the `tablewriter` tag, then the `json` tag, then the field name,
+// and a tag of "-" skips the field
+func (t *Table) AppendStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("tablewriter: cannot append %T as a struct", v)
+	}
+	if len(t.headers) == 0 {
+		t.SetHeader(structHeader(rv.Type()))
+	}
+	row := []string{}
+	fields := structFields(rv.Type())
+	for _, f := range fields {
+		row = append(row, fmt.Sprint(rv.Field(f).Interface()))
+	}
+	t.Append(row)
+	return nil
+}
+
+// Append rows from a slice of structs or struct pointers
+// Every element is appended as with AppendStruct and nil pointers are
+// skipped. The header is derived from the element type even when empty
+func (t *Table) AppendStructs(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("tablewriter: cannot append %T as a slice of structs", v)
+	}
+	elem := rv.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("tablewriter: cannot append %T as a slice of structs", v)
+	}
+	if len(t.headers) == 0 {
+		t.SetHeader(structHeader(elem))
+	}
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i)
+		if item.Kind() == reflect.Ptr && item.IsNil() {
+			continue
+		}
+		if err := t.AppendStruct(item.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the column names of a struct type
+func structHeader(typ reflect.Type) []string {
+	header := []string{}
+	for _, f := range structFields(typ) {
+		header = append(header, fieldName(typ.Field(f)))
+	}
+	return header
+}
+
+// Return the indexes of the exported fields that are not skipped
+func structFields(typ reflect.Type) []int {
+	fields := []int{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" || fieldName(f) == "-" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return fields
+}
+
+// Return the column name of a struct field
+func fieldName(f reflect.StructField) string {
+	if name, ok := f.Tag.Lookup("tablewriter"); ok && name != "" {
+		return name
+	}
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
@@ -9,24 +9,60 @@
 package tablewriter
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 const (
-	MAX_ROW_WIDTH = 30
+	MAX_ROW_WIDTH      = 30
+	MAX_CAPTION_BUFFER = 4096
 )
 
 const (
-	CENTER  = "+"
-	ROW     = "-"
-	COLUMN  = "|"
-	SPACE   = " "
-	NEWLINE = "\n"
+	CENTER   = "+"
+	ROW      = "-"
+	COLUMN   = "|"
+	SPACE    = " "
+	NEWLINE  = "\n"
+	ELLIPSIS = "…"
 )
 
+const (
+	BOLD = 1
+)
+
+// SGR foreground colors
+const (
+	FgBlackColor = iota + 30
+	FgRedColor
+	FgGreenColor
+	FgYellowColor
+	FgBlueColor
+	FgMagentaColor
+	FgCyanColor
+	FgWhiteColor
+)
+
+// SGR background colors
+const (
+	BgBlackColor = iota + 40
+	BgRedColor
+	BgGreenColor
+	BgYellowColor
+	BgBlueColor
+	BgMagentaColor
+	BgCyanColor
+	BgWhiteColor
+)
+
+// SGR attributes of a cell, such as a foreground, a background and BOLD
+type Colors []int
+
 const (
 	ALIGN_DEFAULT = iota
 	ALIGN_CENTER
@@ -34,9 +70,61 @@ const (
 	ALIGN_LEFT
 )
 
+const (
+	FOOTER_RULE_MINIMAL = iota
+	FOOTER_RULE_FULL
+	FOOTER_RULE_NONE
+)
+
+const (
+	VALIGN_TOP = iota
+	VALIGN_MIDDLE
+	VALIGN_BOTTOM
+)
+
+const (
+	ELLIPSIS_END = iota
+	ELLIPSIS_START
+)
+
+const (
+	CASE_UPPER = iota
+	CASE_NONE
+	CASE_LOWER
+	CASE_TITLE
+)
+
+type Style int
+
+const (
+	StyleDefault Style = iota
+	StyleAccessible
+	StyleASCII
+	StyleUnicode
+)
+
+// Positions of a horizontal rule in the table
+const (
+	ruleTop = iota
+	ruleMid
+	ruleBottom
+)
+
+// Left, junction and right glyphs of the top, middle and bottom rules
+type boxGlyphs [3][3]string
+
+var unicodeBox = boxGlyphs{
+	ruleTop:    {"┌", "┬", "┐"},
+	ruleMid:    {"├", "┼", "┤"},
+	ruleBottom: {"└", "┴", "┘"},
+}
+
 var (
-	decimal = regexp.MustCompile(`^-*\d*\.?\d*$`)
-	percent = regexp.MustCompile(`^-*\d*\.?\d*$%$`)
+	decimal  = regexp.MustCompile(`^-*\d*\.?\d*$`)
+	percent  = regexp.MustCompile(`^-*\d*\.?\d*%$`)
+	currency = regexp.MustCompile(`^-*[$€£¥]?(\d{1,3}(,\d{3})*|\d+)(\.\d*)?$`)
+	negative = regexp.MustCompile(`^-[$€£¥]?[\d,]+(\.\d*)?$`)
+	parens   = regexp.MustCompile(`^\([$€£¥]?[\d,]+(\.\d*)?\)$`)
 )
 
 type Border struct {
@@ -46,88 +134,424 @@ type Border struct {
 	Bottom bool
 }
 
+type cellKey struct {
+	row, col int
+}
+
 type Table struct {
-	out         io.Writer
-	rows        [][]string
-	lines       [][][]string
-	cs          map[int]int
-	rs          map[int]int
-	headers     []string
-	footers     []string
-	caption     bool
-	captionText string
-	autoFmt     bool
-	autoWrap    bool
-	mW          int
-	pCenter     string
-	pRow        string
-	pColumn     string
-	tColumn     int
-	tRow        int
-	hAlign      int
-	fAlign      int
-	align       int
-	newLine     string
-	rowLine     bool
-	hdrLine     bool
-	borders     Border
-	colSize     int
+	out           io.Writer
+	rows          [][]string
+	lines         [][][]string
+	cs            map[int]int
+	rs            map[int]int
+	headers       []string
+	footers       []string
+	caption       bool
+	captionText   string
+	captionAlign  int
+	autoFmt       bool
+	autoWrap      bool
+	mW            int
+	pCenter       string
+	pRow          string
+	pColumn       string
+	tColumn       int
+	tRow          int
+	hAlign        int
+	fAlign        int
+	align         int
+	newLine       string
+	rowLine       bool
+	hdrLine       bool
+	borders       Border
+	colSize       int
+	maxCols       int
+	skipEmpty     bool
+	style         Style
+	hCase         int
+	exportNL      int
+	autoTrunc     bool
+	ellipsis      string
+	colTypes      map[int]ColumnType
+	timeLayout    string
+	sortCol       int
+	sortDesc      bool
+	captionGap    int
+	split         map[int]bool
+	fJunction     string
+	brackets      map[cellKey][2]string
+	rowColors     map[int][]int
+	accounting    map[int]bool
+	fRule         int
+	emptyText     string
+	captionAnchor int
+	fAutoNum      bool
+	cellWrap      map[cellKey]bool
+	labels        []string
+	labelHeader   string
+	boldHdr       bool
+	captionLabel  string
+	captionSep    string
+	filter        func([]string) bool
+	recalcFilter  bool
+	minWidth      int
+	transform     func(row, col int, value string) string
+	smartWrap     bool
+	hdrSep        bool
+	hashHeader    string
+	hashFn        func([]string) string
+	maxRows       int
+	truncFmt      func(int) string
+	decimals      map[int][2]int
+	frozen        bool
+	head          string
+	keepBlank     bool
+	autoIndex     bool
+	rtl           bool
+	maxHeight     int
+	hdrGap        int
+	interior      bool
+	colMax        map[int]int
+	csvBOM        bool
+	colAlign      []int
+	aggregates    map[int]func([]string) string
+	aggAlign      int
+	aggAligns     map[int]int
+	zeroPad       map[int]int
+	colMin        map[int]int
+	hSpans        map[int]int
+	spanFill      string
+	fWidth        bool
+	legend        []string
+	hFollow       bool
+	truncCols     map[int]bool
+	box           *boxGlyphs
+	trimBlank     bool
+	autoMerge     bool
+	hColors       []Colors
+	colColors     []Colors
+	ellSides      map[int]int
+	stripes       [2]Colors
+	vAlign        int
+	boxTitle      string
+	catCol        int
+	catColors     map[string][]int
+	alignOn       map[int]rune
+	hidden        map[int]bool
+	fAutoFmt      bool
+	src           [][]string
 }
 
 // Start New Table
 // Take io.Writer Directly
 func NewWriter(writer io.Writer) *Table {
 	t := &Table{
-		out:         writer,
-		rows:        [][]string{},
-		lines:       [][][]string{},
-		cs:          make(map[int]int),
-		rs:          make(map[int]int),
-		headers:     []string{},
-		footers:     []string{},
-		caption:     false,
-		captionText: "Table caption.",
-		autoFmt:     true,
-		autoWrap:    true,
-		mW:          MAX_ROW_WIDTH,
-		pCenter:     CENTER,
-		pRow:        ROW,
-		pColumn:     COLUMN,
-		tColumn:     -1,
-		tRow:        -1,
-		hAlign:      ALIGN_DEFAULT,
-		fAlign:      ALIGN_DEFAULT,
-		align:       ALIGN_DEFAULT,
-		newLine:     NEWLINE,
-		rowLine:     false,
-		hdrLine:     true,
-		borders:     Border{Left: true, Right: true, Bottom: true, Top: true},
-		colSize:     -1}
+		out:           writer,
+		rows:          [][]string{},
+		lines:         [][][]string{},
+		cs:            make(map[int]int),
+		rs:            make(map[int]int),
+		headers:       []string{},
+		footers:       []string{},
+		caption:       false,
+		captionText:   "Table caption.",
+		captionAlign:  ALIGN_DEFAULT,
+		autoFmt:       true,
+		autoWrap:      true,
+		mW:            MAX_ROW_WIDTH,
+		pCenter:       CENTER,
+		pRow:          ROW,
+		pColumn:       COLUMN,
+		tColumn:       -1,
+		tRow:          -1,
+		hAlign:        ALIGN_DEFAULT,
+		fAlign:        ALIGN_DEFAULT,
+		align:         ALIGN_DEFAULT,
+		newLine:       NEWLINE,
+		rowLine:       false,
+		hdrLine:       true,
+		borders:       Border{Left: true, Right: true, Bottom: true, Top: true},
+		colSize:       -1,
+		maxCols:       0,
+		skipEmpty:     false,
+		style:         StyleDefault,
+		hCase:         CASE_UPPER,
+		exportNL:      EXPORT_NEWLINE_DEFAULT,
+		autoTrunc:     false,
+		ellipsis:      ELLIPSIS,
+		colTypes:      make(map[int]ColumnType),
+		timeLayout:    TIME_LAYOUT,
+		sortCol:       -1,
+		sortDesc:      false,
+		captionGap:    0,
+		split:         make(map[int]bool),
+		fJunction:     "",
+		brackets:      make(map[cellKey][2]string),
+		rowColors:     make(map[int][]int),
+		accounting:    make(map[int]bool),
+		fRule:         FOOTER_RULE_MINIMAL,
+		emptyText:     "",
+		captionAnchor: -1,
+		fAutoNum:      false,
+		cellWrap:      make(map[cellKey]bool),
+		labels:        []string{},
+		labelHeader:   "",
+		boldHdr:       false,
+		captionLabel:  "",
+		captionSep:    ": ",
+		filter:        nil,
+		recalcFilter:  false,
+		minWidth:      0,
+		transform:     nil,
+		smartWrap:     false,
+		hdrSep:        true,
+		hashHeader:    "",
+		hashFn:        nil,
+		maxRows:       0,
+		truncFmt:      nil,
+		decimals:      nil,
+		frozen:        false,
+		head:          "",
+		keepBlank:     false,
+		autoIndex:     false,
+		rtl:           false,
+		maxHeight:     0,
+		hdrGap:        0,
+		interior:      true,
+		colMax:        make(map[int]int),
+		csvBOM:        false,
+		colAlign:      []int{},
+		aggregates:    make(map[int]func([]string) string),
+		aggAlign:      ALIGN_DEFAULT,
+		aggAligns:     nil,
+		zeroPad:       make(map[int]int),
+		colMin:        make(map[int]int),
+		hSpans:        make(map[int]int),
+		spanFill:      "",
+		fWidth:        true,
+		legend:        []string{},
+		hFollow:       false,
+		truncCols:     make(map[int]bool),
+		box:           nil,
+		trimBlank:     true,
+		autoMerge:     false,
+		hColors:       nil,
+		colColors:     nil,
+		ellSides:      make(map[int]int),
+		stripes:       [2]Colors{},
+		vAlign:        VALIGN_TOP,
+		boxTitle:      "",
+		catCol:        -1,
+		catColors:     nil,
+		alignOn:       make(map[int]rune),
+		hidden:        make(map[int]bool),
+		fAutoFmt:      true,
+		src:           nil}
 	return t
 }
 
 // Render table output
-func (t Table) Render() {
-	if t.borders.Top {
-		t.printLine(true)
+// Rendering stops at the first failed write and its error is returned
+func (t Table) Render() error {
+	w := &errWriter{w: t.out}
+	t.out = w
+	t.render()
+	return w.err
+}
+
+// Write the table to its output
+func (t Table) render() {
+	t = t.prepared()
+	// A table without columns is sized to frame its empty text
+	if len(t.cs) == 0 && t.emptyText != "" {
+		t.cs = map[int]int{0: DisplayWidth(t.emptyText)}
+	}
+	t.draw()
+}
+
+// Write the prepared table to its output
+func (t Table) draw() {
+	if t.style == StyleAccessible {
+		t.mark(LineRow)
+		t.printAccessible()
+		t.mark(LineCaption)
+		if t.caption {
+			t.printCaption()
+		}
+		t.printLegend()
+		return
+	}
+	if t.maxCols > 0 && len(t.cs) > t.maxCols {
+		t.renderBlocks()
+		return
+	}
+	if t.frozen {
+		t.mark(LineHeader)
+		fmt.Fprint(t.out, t.head)
+	} else {
+		if t.borders.Top {
+			t.printTop()
+		}
+		t.printHeading()
 	}
-	t.printHeading()
 	t.printRows()
+	if len(t.lines) == 0 && t.emptyText != "" {
+		t.mark(LineRow)
+		t.printEmpty()
+	}
 
-	if !t.rowLine && t.borders.Bottom {
-		t.printLine(true)
+	if t.borders.Bottom {
+		t.printFooterLine()
 	}
 	t.printFooter()
+	t.mark(LineCaption)
 	if t.caption {
 		t.printCaption()
 	}
+	t.printLegend()
+}
+
+// errWriter keeps the first write error and drops every later write
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	if err != nil {
+		e.err = err
+	}
+	return n, err
+}
+
+// Build the copy of the table that is actually rendered
+func (t Table) prepared() Table {
+	if t.filter != nil && t.recalcFilter {
+		t = t.filtered()
+	}
+	if len(t.aggregates) > 0 {
+		t = t.aggregated()
+	}
+	if len(t.hidden) > 0 {
+		t = t.visible()
+	}
+	if t.hashFn != nil {
+		t = t.hashed()
+	}
+	if len(t.labels) > 0 {
+		t = t.labelled()
+	}
+	if t.autoIndex {
+		t = t.indexed()
+	}
+	if t.rtl {
+		t = t.mirrored()
+	}
+	t = t.decimalAligned()
+	if t.minWidth > 0 {
+		t = t.widened()
+	}
+	return t
+}
+
+// Build a prepared copy of the table for text exports
+// Cells keep their own line breaks instead of wrapping or truncating to
+// the column widths, every other setting applies as when rendering
+func (t *Table) exported() Table {
+	c := *t
+	c.autoWrap = false
+	c.autoTrunc = false
+	c.truncCols = make(map[int]bool)
+	c.cellWrap = make(map[cellKey]bool)
+	c.maxHeight = 0
+	c.frozen = false
+	c.Recalculate()
+	return c.prepared()
+}
+
+// Return the text of a prepared cell with its lines joined by newlines
+func (t Table) cellText(row, col int) string {
+	if row < len(t.lines) && col < len(t.lines[row]) {
+		return strings.Join(t.lines[row][col], nl)
+	}
+	return ""
+}
+
+// Freeze the table layout
+// Column widths are locked, so later rows wrap or are truncated to fit,
+// and the heading is rendered once and reused by every later Render
+func (t *Table) FreezeLayout() {
+	var buf bytes.Buffer
+	p := t.prepared()
+	p.out = &buf
+	if p.borders.Top {
+		p.printTop()
+	}
+	p.printHeading()
+	t.head = buf.String()
+	t.frozen = true
+}
+
+// Render only the data rows
+// Combined with FreezeLayout this redraws a table body at stable widths
+func (t Table) RenderBody(writer io.Writer) error {
+	var buf bytes.Buffer
+	t = t.prepared()
+	t.out = &buf
+	t.printRows()
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+// Render table output to a string
+func (t Table) RenderString() string {
+	var buf bytes.Buffer
+	t.out = &buf
+	t.Render()
+	return buf.String()
+}
+
+// Render table output without any ANSI escape sequences
+// Useful to write a clean copy of a colored table to a log file
+func (t Table) RenderPlain(writer io.Writer) error {
+	var buf bytes.Buffer
+	t.out = &buf
+	t.Render()
+	_, err := io.WriteString(writer, ansi.ReplaceAllLiteralString(buf.String(), ""))
+	return err
+}
+
+// Render table output into a grid of runes, one slice per line
+// Every slice spans the full table width, so each rune maps to one terminal cell.
+// Wide characters are followed by a zero rune for the cell they cover
+func (t Table) RenderGrid() [][]rune {
+	var buf bytes.Buffer
+	t.out = &buf
+	t.Render()
+	text := strings.TrimSuffix(ansi.ReplaceAllLiteralString(buf.String(), ""), t.newLine)
+	lines := strings.Split(text, t.newLine)
+	width := 0
+	for _, line := range lines {
+		if w := DisplayWidth(line); w > width {
+			width = w
+		}
+	}
+	grid := make([][]rune, len(lines))
+	for i, line := range lines {
+		grid[i] = gridRow(line, width)
+	}
+	return grid
 }
 
 // Set table header
 func (t *Table) SetHeader(keys []string) {
 	t.colSize = len(keys)
 	for i, v := range keys {
-		t.parseDimension(v, i, -1)
+		t.parseHeader(v, i)
 		t.headers = append(t.headers, v)
 	}
 }
@@ -136,7 +560,7 @@ func (t *Table) SetHeader(keys []string) {
 func (t *Table) SetFooter(keys []string) {
 	//t.colSize = len(keys)
 	for i, v := range keys {
-		t.parseDimension(v, i, -1)
+		t.parseFooter(v, i)
 		t.footers = append(t.footers, v)
 	}
 }
@@ -149,26 +573,222 @@ func (t *Table) SetCaption(caption bool, captionText ...string) {
 	}
 }
 
+// Set Caption Alignment
+// Wrapped caption lines are padded against the table width
+func (t *Table) SetCaptionAlignment(align int) {
+	t.captionAlign = align
+}
+
+// Set Caption Label
+// This would prefix the caption with a label such as "Table 1"
+func (t *Table) SetCaptionLabel(label string) {
+	t.captionLabel = label
+}
+
+// Set the separator between caption label and text. Default is ": "
+func (t *Table) SetCaptionLabelSeparator(sep string) {
+	t.captionSep = sep
+}
+
+// Set Caption Anchor
+// This would indent the caption to start under the given column
+func (t *Table) SetCaptionAnchor(col int) {
+	t.captionAnchor = col
+}
+
+// Set Legend
+// This would print the entries below the table and its caption,
+// such as explanations of the symbols used in cells
+func (t *Table) SetLegend(entries []string) {
+	t.legend = entries
+}
+
+// Set Caption Gap
+// This would print n blank lines between the table and its caption
+func (t *Table) SetCaptionGap(n int) {
+	t.captionGap = n
+}
+
 // Turn header autoformatting on/off. Default is on (true).
 func (t *Table) SetAutoFormatHeaders(auto bool) {
 	t.autoFmt = auto
 }
 
+// Turn footer title case on/off. Default is on (true).
+// When off, footers keep their case while underscores and dots are still
+// replaced by spaces. Has no effect when header autoformatting is off
+func (t *Table) SetFooterAutoFormat(auto bool) {
+	t.fAutoFmt = auto
+}
+
+// Set Bold Headers
+// This would wrap every header in the bold terminal attribute
+func (t *Table) SetBoldHeaders(bold bool) {
+	t.boldHdr = bold
+}
+
+// Turn the header column separators on/off. Default is on (true).
+// When off, header columns are split by spaces while the outer border stays
+func (t *Table) SetHeaderSeparatorVisible(visible bool) {
+	t.hdrSep = visible
+}
+
+// Set Header Span
+// This would stretch the header of col over span columns. The headers
+// of the covered columns are not printed
+func (t *Table) SetHeaderSpan(col, span int) {
+	t.hSpans[col] = span
+}
+
+// Set the fill of the top line junctions inside header spans
+// Defaults to the row separator, a space hides the junctions
+func (t *Table) SetSpanFillChar(fill string) {
+	t.spanFill = fill
+}
+
+// Set Header Gap
+// This would print n empty lines between the header and the first row
+func (t *Table) SetHeaderGap(n int) {
+	t.hdrGap = n
+}
+
+// Turn header alignment by column on/off. Default is off (false).
+// When on, every header takes the alignment of its column data,
+// so headers of numeric columns are aligned right
+func (t *Table) SetHeaderFollowsColumnAlign(follow bool) {
+	t.hFollow = follow
+}
+
+// Set Header Case
+// Choose how auto formatted headers are cased: CASE_UPPER (default),
+// CASE_NONE, CASE_LOWER or CASE_TITLE
+func (t *Table) SetHeaderCase(mode int) {
+	t.hCase = mode
+}
+
 // Turn automatic multiline text adjustment on/off. Default is on (true).
 func (t *Table) SetAutoWrapText(auto bool) {
 	t.autoWrap = auto
 }
 
+// Turn truncation of long cells on/off. Default is off (false).
+// Truncated cells end with the ellipsis instead of wrapping
+func (t *Table) SetAutoTruncate(auto bool) {
+	t.autoTrunc = auto
+}
+
+// Set Truncate
+// This would turn truncation on/off for a single column,
+// overriding SetAutoTruncate
+func (t *Table) SetTruncate(column int, enabled bool) {
+	t.truncCols[column] = enabled
+	if len(t.lines) > 0 {
+		t.Recalculate()
+	}
+}
+
+// Check if the cells of a column are truncated instead of wrapped
+func (t Table) truncated(col int) bool {
+	if v, ok := t.truncCols[col]; ok {
+		return v
+	}
+	return t.autoTrunc
+}
+
+// Set Ellipsis Side
+// This would place the ellipsis of truncated cells in a column at the
+// end (ELLIPSIS_END) or, for right aligned text, at the start (ELLIPSIS_START)
+func (t *Table) SetEllipsisSide(col int, side int) {
+	t.ellSides[col] = side
+	if len(t.lines) > 0 {
+		t.Recalculate()
+	}
+}
+
+// Truncate a cell of col to width on the ellipsis side of the column
+func (t Table) truncateCell(s string, width, col int) string {
+	if t.ellSides[col] == ELLIPSIS_START {
+		return truncateStart(s, width, t.ellipsis)
+	}
+	return Truncate(s, width, t.ellipsis)
+}
+
+// Set the Ellipsis used to mark truncated text. Default is "…"
+func (t *Table) SetEllipsis(ellipsis string) {
+	t.ellipsis = ellipsis
+}
+
 // Set the Default column width
+// A width of 0 or less means unlimited, so cells are never wrapped
 func (t *Table) SetColWidth(width int) {
 	t.mW = width
 }
 
+// Turn blank line preservation on/off. Default is off (false).
+// A cell holding only whitespace, such as "\n\n", renders as a single
+// empty line. When on, it keeps one empty line per line of input
+func (t *Table) SetPreserveBlankLines(keep bool) {
+	t.keepBlank = keep
+}
+
+// Turn automatic merging of repeated cells on/off. Default is off (false).
+// When on, a cell equal to the cell above it is left blank and the row
+// line between them is left out for that column
+func (t *Table) SetAutoMergeCells(auto bool) {
+	t.autoMerge = auto
+}
+
+// Turn trimming of whitespace-only cells on/off. Default is on (true).
+// When off, a cell such as "   " keeps its spaces and widens its column
+func (t *Table) SetTrimWhitespaceOnlyCells(trim bool) {
+	t.trimBlank = trim
+}
+
+// Set Maximum Row Height
+// Cells are wrapped as usual but cut at n lines, with an ellipsis
+// ending the last visible line. A value of 0 means unlimited
+func (t *Table) SetMaxRowHeight(n int) {
+	t.maxHeight = n
+}
+
+// Turn smart wrapping on/off. Default is off (false).
+// When on, closing punctuation never starts a wrapped line on its own
+func (t *Table) SetSmartWrap(smart bool) {
+	t.smartWrap = smart
+}
+
+// Set Column Max Width
+// This would override the maximum width for a single column
+func (t *Table) SetColMaxWidth(column int, width int) {
+	t.colMax[column] = width
+	if len(t.lines) > 0 {
+		t.Recalculate()
+	}
+}
+
+// Set Column Min Width
+// This would keep a column at least width characters wide
+func (t *Table) SetColMinWidth(column int, width int) {
+	t.colMin[column] = width
+	if len(t.lines) > 0 {
+		t.Recalculate()
+	}
+}
+
 // Set the Column Separator
+// Separators may contain ANSI color codes, they never affect column widths
+// The column separator is independent of the row and center separators,
+// so box drawing verticals can be mixed with ASCII rules
 func (t *Table) SetColumnSeparator(sep string) {
 	t.pColumn = sep
 }
 
+// Turn interior column separators on/off. Default is on (true).
+// When off, columns are split by spaces and only the outer border is drawn
+func (t *Table) SetInteriorSeparators(interior bool) {
+	t.interior = interior
+}
+
 // Set the Row Separator
 func (t *Table) SetRowSeparator(sep string) {
 	t.pRow = sep
@@ -184,6 +804,26 @@ func (t *Table) SetHeaderAlignment(hAlign int) {
 	t.hAlign = hAlign
 }
 
+// Set Footer Junction
+// This would use a distinct junction on the line between body and footer
+func (t *Table) SetFooterJunction(junction string) {
+	t.fJunction = junction
+}
+
+// Set Footer Rule Mode
+// Choose the line printed under the footer: FOOTER_RULE_MINIMAL (default)
+// only underlines non-empty footer cells when borders are off,
+// FOOTER_RULE_FULL spans the whole table and FOOTER_RULE_NONE prints nothing
+func (t *Table) SetFooterRuleMode(mode int) {
+	t.fRule = mode
+}
+
+// Turn numeric footer auto alignment on/off. Default is off (false).
+// When on, numeric footer cells align right like the numbers above them
+func (t *Table) SetFooterAutoNumericAlign(auto bool) {
+	t.fAutoNum = auto
+}
+
 // Set Footer Alignment
 func (t *Table) SetFooterAlignment(fAlign int) {
 	t.fAlign = fAlign
@@ -194,6 +834,34 @@ func (t *Table) SetAlignment(align int) {
 	t.align = align
 }
 
+// Set Column Alignment
+// Every entry aligns the data cells of one column. Columns without an entry,
+// or with ALIGN_DEFAULT, follow the table alignment
+func (t *Table) SetColumnAlignment(alignments []int) {
+	t.colAlign = alignments
+}
+
+// Hide Column
+// This would leave the column out of the rendered table while keeping
+// its data, so it can be shown again with ShowColumn. Sorting and
+// category colors set on a hidden column are not applied
+func (t *Table) HideColumn(column int) {
+	t.hidden[column] = true
+}
+
+// Show a column hidden with HideColumn
+func (t *Table) ShowColumn(column int) {
+	delete(t.hidden, column)
+}
+
+// Set Column Align On
+// This would line up the cells of a column on the first occurrence of
+// ch, such as the colon of HH:MM durations. Cells are padded on both
+// sides of it the way decimal columns line up their decimal points
+func (t *Table) SetColumnAlignOn(col int, ch rune) {
+	t.alignOn[col] = ch
+}
+
 // Set New Line
 func (t *Table) SetNewLine(nl string) {
 	t.newLine = nl
@@ -211,56 +879,1189 @@ func (t *Table) SetRowLine(line bool) {
 	t.rowLine = line
 }
 
-// Set Table Border
-// This would enable / disable line around the table
-func (t *Table) SetBorder(border bool) {
-	t.SetBorders(Border{border, border, border, border})
+// Set Table Border
+// This would enable / disable line around the table
+func (t *Table) SetBorder(border bool) {
+	t.SetBorders(Border{border, border, border, border})
+}
+
+func (t *Table) SetBorders(border Border) {
+	t.borders = border
+}
+
+// Set Table Style
+// StyleAccessible renders every row as "Field: value" lines without
+// any box drawing, which reads better with screen readers and log scrapers.
+// StyleUnicode draws the box with Unicode line characters and proper
+// corners, StyleASCII restores the default +, - and | separators
+func (t *Table) SetStyle(style Style) {
+	t.style = style
+	switch style {
+	case StyleUnicode:
+		t.pCenter, t.pRow, t.pColumn = "┼", "─", "│"
+		t.box = &unicodeBox
+	case StyleASCII:
+		t.pCenter, t.pRow, t.pColumn = CENTER, ROW, COLUMN
+		t.box = nil
+	}
+}
+
+// Turn the UTF-8 byte order mark for WriteCSV on/off. Default is off (false).
+// Spreadsheet applications on Windows need it to detect UTF-8 text
+func (t *Table) SetCSVUTF8BOM(bom bool) {
+	t.csvBOM = bom
+}
+
+// Set Export Newline Mode
+// Controls how newlines inside cells are written by WriteCSV and WriteTSV
+func (t *Table) SetExportNewlineMode(mode int) {
+	t.exportNL = mode
+}
+
+// Set Empty Table Text
+// This would print a centered message such as "(no data)" under the
+// header when the table has no rows
+func (t *Table) SetEmptyTableText(text string) {
+	t.emptyText = text
+}
+
+// Set Row Labels
+// This would prepend a stub column with one label per data row
+func (t *Table) SetRowLabels(labels []string) {
+	t.labels = labels
+}
+
+// Set the header of the row label column
+func (t *Table) SetRowLabelHeader(header string) {
+	t.labelHeader = header
+}
+
+// Turn the auto index column on/off. Default is off (false).
+// When on, rows are numbered by their display position starting at 1 in
+// a column placed before any labels set with SetRowLabels
+func (t *Table) SetAutoIndex(auto bool) {
+	t.autoIndex = auto
+}
+
+// Turn right-to-left layout on/off. Default is off (false).
+// When on, columns are drawn from right to left, so the first column
+// and any row label or index column appear on the right
+func (t *Table) SetRTL(rtl bool) {
+	t.rtl = rtl
+}
+
+// Set Cell Transform
+// The callback is applied to every appended cell before widths are measured.
+// The transformed text replaces the appended text, so sorting, filtering
+// and every export see it too
+func (t *Table) SetCellTransform(fn func(row, col int, value string) string) {
+	t.transform = fn
+}
+
+// Turn footer widths on/off. Default is on (true).
+// When off, footers do not widen their columns and are truncated instead
+func (t *Table) SetFooterAffectsWidth(affects bool) {
+	t.fWidth = affects
+	t.Recalculate()
+}
+
+// Set Footer Aggregate
+// This would fill the footer cell of a column with fn computed over the
+// column cells of the rendered rows, such as a sum
+func (t *Table) SetFooterAggregate(col int, fn func(cells []string) string) {
+	t.aggregates[col] = fn
+}
+
+// Set Footer Aggregate Alignment
+// Aggregate cells follow their column alignment by default, with
+// numbers aligned right. Any other alignment overrides it
+func (t *Table) SetFooterAggregateAlignment(align int) {
+	t.aggAlign = align
+}
+
+// Set Row Hash Column
+// This would append a column with the header holding fn computed
+// over the raw cells of every row, such as a short checksum
+func (t *Table) SetRowHashColumn(header string, fn func(cells []string) string) {
+	t.hashHeader = header
+	t.hashFn = fn
+}
+
+// Set Maximum Rows
+// Only the first n rows are rendered, followed by a line counting the rest.
+// A value of 0 renders every row
+func (t *Table) SetMaxRows(n int) {
+	t.maxRows = n
+}
+
+// Set Truncation Format
+// This would build the line shown in place of rows hidden by SetMaxRows
+func (t *Table) SetTruncationFormat(fn func(hidden int) string) {
+	t.truncFmt = fn
+}
+
+// Set Row Filter
+// Only rows whose cells satisfy the predicate are rendered
+func (t *Table) SetRowFilter(fn func(cells []string) bool) {
+	t.filter = fn
+}
+
+// Turn width recalculation for filtered rows on/off. Default is off (false).
+// When on, widths are computed from the rendered rows only
+func (t *Table) SetRecalcOnFilter(recalc bool) {
+	t.recalcFilter = recalc
+}
+
+// Set Minimum Table Width
+// Narrower tables are widened by expanding the last column,
+// even beyond the maximum column width
+func (t *Table) SetMinTableWidth(width int) {
+	t.minWidth = width
+}
+
+// Set Maximum Columns
+// Wider tables are rendered as stacked blocks of at most n columns
+// with the first column repeated in every block
+func (t *Table) SetMaxColumns(n int) {
+	t.maxCols = n
+}
+
+// Set Skip Empty Rows
+// This would drop appended rows where every cell is empty
+func (t *Table) SetSkipEmptyRows(skip bool) {
+	t.skipEmpty = skip
+}
+
+// Set Accounting Negatives
+// This would render negative numbers of a column in parentheses
+// such as -1234.50 as (1,234.50)
+func (t *Table) SetAccountingNegatives(col int, b bool) {
+	t.accounting[col] = b
+	if len(t.lines) > 0 {
+		t.Recalculate()
+	}
+}
+
+// Set Column Zero Pad
+// This would pad the integers of a column with leading zeros
+// to width characters, such as 7 as 0007
+func (t *Table) SetColumnZeroPad(col, width int) {
+	t.zeroPad[col] = width
+	if len(t.lines) > 0 {
+		t.Recalculate()
+	}
+}
+
+// Set Cell Wrap
+// This would turn wrapping on/off for a single cell, overriding
+// SetAutoWrapText. A cell that does not wrap widens its column
+func (t *Table) SetCellWrap(row, col int, wrap bool) {
+	t.cellWrap[cellKey{row, col}] = wrap
+	if row < len(t.lines) {
+		t.Recalculate()
+	}
+}
+
+// Set Row Color
+// This would color every cell of a data row with the given SGR attributes
+func (t *Table) SetRowColor(row int, attrs ...int) {
+	t.rowColors[row] = attrs
+}
+
+// Set Box Title
+// This would center the title in the top border of the table
+func (t *Table) SetBoxTitle(title string) {
+	t.boxTitle = title
+}
+
+// Set Vertical Alignment
+// This would place the lines of cells shorter than their row at the
+// top (VALIGN_TOP), middle (VALIGN_MIDDLE) or bottom (VALIGN_BOTTOM)
+func (t *Table) SetVerticalAlignment(align int) {
+	t.vAlign = align
+}
+
+// Set Row Colors
+// This would stripe data rows, coloring even rows with even and odd rows
+// with odd in the order they are rendered. Colors set with SetRowColor
+// take precedence
+func (t *Table) SetRowColors(even Colors, odd Colors) {
+	t.stripes = [2]Colors{even, odd}
+}
+
+// Set Category Colors
+// This would color each data row by the value of its cell in col, such
+// as a status column. Values without colors leave the row uncolored.
+// Colors set with SetRowColor take precedence
+func (t *Table) SetCategoryColors(col int, colors map[string][]int) {
+	t.catCol = col
+	t.catColors = colors
+}
+
+// Return the category colors of a row or nil
+func (t Table) categoryColors(row int) []int {
+	if t.catCol < 0 {
+		return nil
+	}
+	return t.catColors[strings.TrimSpace(t.rawCell(row, t.catCol))]
+}
+
+// Set Header Color
+// This would color each header cell with the colors at its column index
+func (t *Table) SetHeaderColor(colors ...Colors) {
+	t.hColors = colors
+}
+
+// Set Column Color
+// This would color the data cells of each column with the colors at its
+// index. A row color set with SetRowColor takes precedence
+func (t *Table) SetColumnColor(colors ...Colors) {
+	t.colColors = colors
+}
+
+// Return the colors at col or nil
+func colorsAt(colors []Colors, col int) Colors {
+	if col < len(colors) {
+		return colors[col]
+	}
+	return nil
+}
+
+// Set Cell Brackets
+// This would wrap a single cell in left and right markers such as [ and ]
+// to highlight it without color. Brackets count toward the column width
+func (t *Table) SetCellBrackets(row, col int, left, right string) {
+	t.brackets[cellKey{row, col}] = [2]string{left, right}
+	if row < len(t.lines) {
+		t.Recalculate()
+	}
+}
+
+// Append row to table
+func (t *Table) Append(row []string) {
+	if t.skipEmpty && isEmptyRow(row) {
+		return
+	}
+
+	rowSize := len(t.headers)
+	if rowSize > t.colSize {
+		t.colSize = rowSize
+	}
+
+	n := len(t.lines)
+	row = t.transformed(n, row)
+	t.rows = append(t.rows, row)
+	line := [][]string{}
+	for i, v := range row {
+		v = t.formatCell(n, i, v)
+
+		// Detect string  width
+		// Detect String height
+		// Break strings into words
+		out := t.parseDimension(v, i, n)
+
+		// Append broken words
+		line = append(line, out)
+	}
+	t.lines = append(t.lines, line)
+}
+
+// Return the row as changed by the cell transform
+// The transformed text is retained so every output sees the same values
+func (t *Table) transformed(n int, row []string) []string {
+	if t.transform == nil {
+		return row
+	}
+	cells := make([]string, len(row))
+	for i, v := range row {
+		cells[i] = t.transform(n, i, v)
+	}
+	return cells
+}
+
+// Apply zero padding, accounting and brackets to a cell
+func (t *Table) formatCell(n, i int, v string) string {
+	if width, ok := t.zeroPad[i]; ok {
+		v = ZeroPad(v, width)
+	}
+	if t.accounting[i] {
+		v = Accounting(v)
+	}
+	if b, ok := t.brackets[cellKey{n, i}]; ok {
+		v = b[0] + v + b[1]
+	}
+	return v
+}
+
+// Append row with cells already split into lines
+// Lines are used as-is without wrapping and widths follow the longest line,
+// unless the layout is frozen and lines are truncated to fit
+func (t *Table) AppendMultiline(row [][]string) {
+	raw := []string{}
+	for _, cell := range row {
+		raw = append(raw, strings.Join(cell, nl))
+	}
+	if t.skipEmpty && isEmptyRow(raw) {
+		return
+	}
+
+	rowSize := len(t.headers)
+	if rowSize > t.colSize {
+		t.colSize = rowSize
+	}
+
+	n := len(t.lines)
+	raw = t.transformed(n, raw)
+	t.rows = append(t.rows, raw)
+	t.split[n] = true
+	line := [][]string{}
+	for i, v := range raw {
+		out := strings.Split(t.formatCell(n, i, v), nl)
+		width, frozen := t.cs[i]
+		frozen = frozen && t.frozen
+		for j, l := range out {
+			if frozen {
+				out[j] = t.truncateCell(l, width, i)
+			} else if w := DisplayWidth(l); w > t.cs[i] {
+				t.cs[i] = w
+			}
+		}
+		if h := len(out); h > t.rs[n] {
+			t.rs[n] = h
+		}
+		line = append(line, out)
+	}
+	t.lines = append(t.lines, line)
+}
+
+// Clone the table settings
+// The clone shares no maps or slices with the table and has no rows,
+// so a configured table can serve as a template
+func (t *Table) Clone() *Table {
+	c := *t
+	c.headers = append([]string{}, t.headers...)
+	c.footers = append([]string{}, t.footers...)
+	c.labels = append([]string{}, t.labels...)
+	c.legend = append([]string{}, t.legend...)
+	c.colAlign = append([]int{}, t.colAlign...)
+	c.hColors = append([]Colors{}, t.hColors...)
+	c.colColors = append([]Colors{}, t.colColors...)
+	c.colTypes = make(map[int]ColumnType)
+	for k, v := range t.colTypes {
+		c.colTypes[k] = v
+	}
+	c.hidden = make(map[int]bool)
+	for k, v := range t.hidden {
+		c.hidden[k] = v
+	}
+	c.alignOn = make(map[int]rune)
+	for k, v := range t.alignOn {
+		c.alignOn[k] = v
+	}
+	c.brackets = make(map[cellKey][2]string)
+	for k, v := range t.brackets {
+		c.brackets[k] = v
+	}
+	c.rowColors = make(map[int][]int)
+	for k, v := range t.rowColors {
+		c.rowColors[k] = append([]int{}, v...)
+	}
+	c.accounting = make(map[int]bool)
+	for k, v := range t.accounting {
+		c.accounting[k] = v
+	}
+	c.ellSides = copyWidths(t.ellSides)
+	if t.catColors != nil {
+		c.catColors = make(map[string][]int)
+		for k, v := range t.catColors {
+			c.catColors[k] = append([]int{}, v...)
+		}
+	}
+	c.truncCols = make(map[int]bool)
+	for k, v := range t.truncCols {
+		c.truncCols[k] = v
+	}
+	c.cellWrap = make(map[cellKey]bool)
+	for k, v := range t.cellWrap {
+		c.cellWrap[k] = v
+	}
+	c.aggregates = make(map[int]func([]string) string)
+	for k, v := range t.aggregates {
+		c.aggregates[k] = v
+	}
+	c.colMax = copyWidths(t.colMax)
+	c.colMin = copyWidths(t.colMin)
+	c.zeroPad = copyWidths(t.zeroPad)
+	c.hSpans = copyWidths(t.hSpans)
+	c.decimals = nil
+	c.aggAligns = nil
+	c.ClearRows()
+	return &c
+}
+
+// Select Columns by header name
+// Keeps only the named columns in the given order. Rows, footers and
+// column settings follow their columns, header spans are dropped
+func (t *Table) SelectColumns(names []string) error {
+	index := make(map[string]int)
+	for i, h := range t.headers {
+		if _, ok := index[h]; !ok {
+			index[h] = i
+		}
+	}
+	cols := make([]int, len(names))
+	pos := make(map[int]int)
+	for i, name := range names {
+		c, ok := index[name]
+		if !ok {
+			return fmt.Errorf("tablewriter: unknown column %q", name)
+		}
+		cols[i] = c
+		pos[c] = i
+	}
+
+	t.headers = pick(t.headers, cols)
+	t.footers = pick(t.footers, cols)
+	t.hColors = pickColors(t.hColors, cols)
+	t.colColors = pickColors(t.colColors, cols)
+	rows := make([][]string, len(t.rows))
+	for n, row := range t.rows {
+		rows[n] = make([]string, len(cols))
+		for i, c := range cols {
+			if c < len(row) {
+				rows[n][i] = row[c]
+			}
+		}
+	}
+	t.rows = rows
+
+	colAlign := make([]int, len(cols))
+	for i, c := range cols {
+		if c < len(t.colAlign) {
+			colAlign[i] = t.colAlign[c]
+		}
+	}
+	t.colAlign = colAlign
+	colTypes := make(map[int]ColumnType)
+	for k, v := range t.colTypes {
+		if i, ok := pos[k]; ok {
+			colTypes[i] = v
+		}
+	}
+	t.colTypes = colTypes
+	alignOn := make(map[int]rune)
+	for k, v := range t.alignOn {
+		if i, ok := pos[k]; ok {
+			alignOn[i] = v
+		}
+	}
+	t.alignOn = alignOn
+	hidden := make(map[int]bool)
+	for k, v := range t.hidden {
+		if i, ok := pos[k]; ok {
+			hidden[i] = v
+		}
+	}
+	t.hidden = hidden
+	accounting := make(map[int]bool)
+	for k, v := range t.accounting {
+		if i, ok := pos[k]; ok {
+			accounting[i] = v
+		}
+	}
+	t.accounting = accounting
+	truncCols := make(map[int]bool)
+	for k, v := range t.truncCols {
+		if i, ok := pos[k]; ok {
+			truncCols[i] = v
+		}
+	}
+	t.truncCols = truncCols
+	aggregates := make(map[int]func([]string) string)
+	for k, v := range t.aggregates {
+		if i, ok := pos[k]; ok {
+			aggregates[i] = v
+		}
+	}
+	t.aggregates = aggregates
+	brackets := make(map[cellKey][2]string)
+	for k, v := range t.brackets {
+		if i, ok := pos[k.col]; ok {
+			brackets[cellKey{k.row, i}] = v
+		}
+	}
+	t.brackets = brackets
+	cellWrap := make(map[cellKey]bool)
+	for k, v := range t.cellWrap {
+		if i, ok := pos[k.col]; ok {
+			cellWrap[cellKey{k.row, i}] = v
+		}
+	}
+	t.cellWrap = cellWrap
+	t.colMax = remapColumns(t.colMax, pos)
+	t.colMin = remapColumns(t.colMin, pos)
+	t.zeroPad = remapColumns(t.zeroPad, pos)
+	t.ellSides = remapColumns(t.ellSides, pos)
+	t.aggAligns = remapColumns(t.aggAligns, pos)
+	t.hSpans = make(map[int]int)
+
+	if i, ok := pos[t.catCol]; ok {
+		t.catCol = i
+	} else {
+		t.catCol = -1
+	}
+	if i, ok := pos[t.sortCol]; ok {
+		t.sortCol = i
+	} else {
+		t.sortCol = -1
+	}
+	if i, ok := pos[t.captionAnchor]; ok {
+		t.captionAnchor = i
+	} else {
+		t.captionAnchor = -1
+	}
+	t.colSize = -1
+	t.Recalculate()
+	return nil
+}
+
+// Move the values of a column keyed map to their new columns
+// Values of columns missing from pos are dropped
+func remapColumns(m map[int]int, pos map[int]int) map[int]int {
+	out := make(map[int]int)
+	for k, v := range m {
+		if i, ok := pos[k]; ok {
+			out[i] = v
+		}
+	}
+	return out
+}
+
+// Clear Rows
+// This would drop every row while keeping the header, footer and all
+// settings. Column widths shrink back to the header and footer
+func (t *Table) ClearRows() {
+	t.rows = [][]string{}
+	t.Recalculate()
+}
+
+// Recalculate column widths and row heights
+// Retained rows are parsed again with the current settings, which is
+// needed when widths or wrapping are changed after appending
+func (t *Table) Recalculate() {
+	t.rebuild(nil)
+}
+
+// Parse retained rows again, skipped rows keep their index but are
+// left empty so they do not count toward widths and heights
+func (t *Table) rebuild(skip func([]string) bool) {
+	rows, split := t.rows, t.split
+	// Retained rows were transformed when they were appended
+	transform := t.transform
+	t.transform = nil
+	defer func() { t.transform = transform }()
+	t.rows = [][]string{}
+	t.lines = [][][]string{}
+	// A frozen layout keeps its widths and rows are fitted into them
+	if t.frozen {
+		t.cs = copyWidths(t.cs)
+	} else {
+		t.cs = make(map[int]int)
+	}
+	t.rs = make(map[int]int)
+	t.split = make(map[int]bool)
+
+	for i, v := range t.headers {
+		t.parseHeader(v, i)
+	}
+	for i, v := range t.footers {
+		t.parseFooter(v, i)
+	}
+	for n, row := range rows {
+		if skip != nil && skip(row) {
+			t.rows = append(t.rows, row)
+			t.lines = append(t.lines, [][]string{})
+			continue
+		}
+		if !split[n] {
+			t.Append(row)
+			continue
+		}
+		cells := [][]string{}
+		for _, v := range row {
+			cells = append(cells, strings.Split(v, nl))
+		}
+		t.AppendMultiline(cells)
+	}
+}
+
+// Check if every cell of a row is blank
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Allow Support for Bulk Append
+// Eliminates repeated for loops
+func (t *Table) AppendBulk(rows [][]string) {
+	for _, row := range rows {
+		t.Append(row)
+	}
+}
+
+// Append rows from maps keyed by column name
+// Without a header, the header becomes the sorted union of all keys.
+// Keys missing from a map are left empty
+func (t *Table) AppendMaps(rows []map[string]string) {
+	if len(t.headers) == 0 {
+		seen := make(map[string]bool)
+		keys := []string{}
+		for _, row := range rows {
+			for k := range row {
+				if !seen[k] {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}
+		}
+		sort.Strings(keys)
+		t.SetHeader(keys)
+	}
+	for _, row := range rows {
+		cells := make([]string, len(t.headers))
+		for i, k := range t.headers {
+			cells[i] = row[k]
+		}
+		t.Append(cells)
+	}
+}
+
+// Render stacked blocks of columns
+// Only the last block carries the caption
+func (t Table) renderBlocks() {
+	blocks := columnBlocks(len(t.cs), t.maxCols)
+	if t.rtl {
+		// Columns are mirrored, the key column is the last one
+		n := len(t.cs)
+		for _, cols := range blocks {
+			for i, j := 0, len(cols)-1; i <= j; i, j = i+1, j-1 {
+				cols[i], cols[j] = n-1-cols[j], n-1-cols[i]
+			}
+		}
+	}
+	for i, cols := range blocks {
+		if i > 0 {
+			fmt.Fprint(t.out, t.newLine)
+		}
+		sub := t.subTable(cols)
+		sub.caption = t.caption && i == len(blocks)-1
+		if i < len(blocks)-1 {
+			sub.legend = nil
+		}
+		sub.draw()
+	}
+}
+
+// Split total columns into groups of at most max columns
+// Column 0 is used as the key and starts every group
+func columnBlocks(total, max int) [][]int {
+	blocks := [][]int{}
+	if max == 1 {
+		for i := 0; i < total; i++ {
+			blocks = append(blocks, []int{i})
+		}
+		return blocks
+	}
+	for i := 1; i < total; i += max - 1 {
+		cols := []int{0}
+		for j := i; j < i+max-1 && j < total; j++ {
+			cols = append(cols, j)
+		}
+		blocks = append(blocks, cols)
+	}
+	return blocks
+}
+
+// Build a copy of the table restricted to the given columns
+func (t Table) subTable(cols []int) Table {
+	sub := t
+	sub.maxCols = 0
+	sub.colSize = len(cols)
+	sub.cs = make(map[int]int)
+	sub.rs = make(map[int]int)
+	sub.headers = pick(t.headers, cols)
+	sub.footers = pick(t.footers, cols)
+	sub.hColors = pickColors(t.hColors, cols)
+	sub.colColors = pickColors(t.colColors, cols)
+	sub.lines = [][][]string{}
+	sub.colTypes = make(map[int]ColumnType)
+	sub.decimals = make(map[int][2]int)
+	sub.alignOn = make(map[int]rune)
+	sub.colAlign = []int{}
+	sub.hSpans = make(map[int]int)
+	sub.aggAligns = make(map[int]int)
+
+	for i, c := range cols {
+		sub.cs[i] = t.cs[c]
+		if typ, ok := t.colTypes[c]; ok {
+			sub.colTypes[i] = typ
+		}
+		if d, ok := t.decimals[c]; ok {
+			sub.decimals[i] = d
+		}
+		if ch, ok := t.alignOn[c]; ok {
+			sub.alignOn[i] = ch
+		}
+		sub.colAlign = append(sub.colAlign, t.columnAlign(c))
+		if align, ok := t.aggAligns[c]; ok {
+			sub.aggAligns[i] = align
+		}
+	}
+	for n, line := range t.lines {
+		row := [][]string{}
+		for _, c := range cols {
+			if c < len(line) {
+				row = append(row, line[c])
+				if h := len(line[c]); h > sub.rs[n] {
+					sub.rs[n] = h
+				}
+			}
+		}
+		sub.lines = append(sub.lines, row)
+	}
+
+	// Rows follow their columns, the filter keeps seeing whole rows
+	sub.src = t.sourceRows()
+	sub.rows = make([][]string, len(t.rows))
+	for n, row := range t.rows {
+		sub.rows[n] = make([]string, len(cols))
+		for i, c := range cols {
+			if c < len(row) {
+				sub.rows[n][i] = row[c]
+			}
+		}
+	}
+	sub.sortCol, sub.catCol = -1, -1
+	for i, c := range cols {
+		if c == t.sortCol {
+			sub.sortCol = i
+		}
+		if c == t.catCol {
+			sub.catCol = i
+		}
+	}
+	return sub
+}
+
+// Return the rows as appended, before render-time copies reshaped them
+func (t Table) sourceRows() [][]string {
+	if t.src != nil {
+		return t.src
+	}
+	return t.rows
+}
+
+// Build a copy of the table measuring the widths before and after the
+// alignment character of every decimal or aligned column, widened to
+// fit them when needed
+func (t Table) decimalAligned() Table {
+	d := t
+	d.decimals = make(map[int][2]int)
+	cols := []int{}
+	for col, typ := range t.colTypes {
+		if typ == ColumnDecimal {
+			cols = append(cols, col)
+		}
+	}
+	for col := range t.alignOn {
+		if t.colTypes[col] != ColumnDecimal {
+			cols = append(cols, col)
+		}
+	}
+	for _, col := range cols {
+		var w [2]int
+		for _, line := range t.lines {
+			if col >= len(line) {
+				continue
+			}
+			for _, v := range line[col] {
+				i, f := t.splitAligned(col, v)
+				if n := DisplayWidth(i); n > w[0] {
+					w[0] = n
+				}
+				if n := DisplayWidth(f); n > w[1] {
+					w[1] = n
+				}
+			}
+		}
+		d.decimals[col] = w
+		if w[0]+w[1] > d.cs[col] {
+			d.cs = copyWidths(d.cs)
+			d.cs[col] = w[0] + w[1]
+		}
+	}
+	return d
+}
+
+// Copy column widths so a render copy never resizes the table
+func copyWidths(cs map[int]int) map[int]int {
+	c := make(map[int]int)
+	for i, v := range cs {
+		c[i] = v
+	}
+	return c
+}
+
+// Split a cell into its integer part and its fraction including the point
+func splitDecimal(s string) (string, string) {
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// Split a cell of col before its alignment character
+// Columns without one split at the decimal point
+func (t Table) splitAligned(col int, s string) (string, string) {
+	ch, ok := t.alignOn[col]
+	if !ok {
+		return splitDecimal(s)
+	}
+	if i := strings.IndexRune(s, ch); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// Line up a cell of col on its alignment character given the
+// column widths before and after it
+func (t Table) alignAt(col int, s string, w [2]int) string {
+	if strings.TrimSpace(s) == "" {
+		return s
+	}
+	i, f := t.splitAligned(col, s)
+	return PadLeft(i, SPACE, w[0]) + PadRight(f, SPACE, w[1])
+}
+
+// Build a copy of the table with the last column widened to
+// reach the minimum table width
+func (t Table) widened() Table {
+	w := t
+	gap := t.minWidth - t.getTableWidth()
+	if gap <= 0 || len(t.cs) == 0 {
+		return w
+	}
+	w.cs = copyWidths(t.cs)
+	w.cs[len(t.cs)-1] += gap
+	return w
+}
+
+// Build a copy of the table sized only from rows passing the filter
+func (t Table) filtered() Table {
+	f := t
+	f.rebuild(func(row []string) bool {
+		return !t.filter(row)
+	})
+	f.recalcFilter = false
+	return f
+}
+
+// Build a copy of the table with the row labels as its first column
+// Column based settings are shifted to follow their columns
+func (t Table) labelled() Table {
+	l := t
+	l.labels = nil
+	l.labelHeader = ""
+	l.src = t.sourceRows()
+	l.cs = make(map[int]int)
+	l.colTypes = make(map[int]ColumnType)
+	l.rows = [][]string{}
+	l.lines = [][][]string{}
+
+	for i, v := range t.cs {
+		l.cs[i+1] = v
+	}
+	for i, typ := range t.colTypes {
+		l.colTypes[i+1] = typ
+	}
+	l.alignOn = make(map[int]rune)
+	for i, ch := range t.alignOn {
+		l.alignOn[i+1] = ch
+	}
+	l.colAlign = append([]int{ALIGN_DEFAULT}, t.colAlign...)
+	if len(t.hColors) > 0 {
+		l.hColors = append([]Colors{nil}, t.hColors...)
+	}
+	if len(t.colColors) > 0 {
+		l.colColors = append([]Colors{nil}, t.colColors...)
+	}
+	l.hSpans = make(map[int]int)
+	for i, n := range t.hSpans {
+		l.hSpans[i+1] = n
+	}
+	if t.aggAligns != nil {
+		l.aggAligns = make(map[int]int)
+		for i, align := range t.aggAligns {
+			l.aggAligns[i+1] = align
+		}
+	}
+	l.cs[0] = DisplayWidth(t.labelHeader)
+	for _, v := range t.labels {
+		if w := DisplayWidth(v); w > l.cs[0] {
+			l.cs[0] = w
+		}
+	}
+
+	if len(t.headers) > 0 {
+		l.headers = append([]string{t.labelHeader}, t.headers...)
+	}
+	if len(t.footers) > 0 {
+		l.footers = append([]string{""}, t.footers...)
+	}
+	for n, line := range t.lines {
+		label := ""
+		if n < len(t.labels) {
+			label = t.labels[n]
+		}
+		l.lines = append(l.lines, append([][]string{[]string{label}}, line...))
+		if n < len(t.rows) {
+			l.rows = append(l.rows, append([]string{label}, t.rows[n]...))
+		}
+	}
+
+	if t.catCol >= 0 {
+		l.catCol = t.catCol + 1
+	}
+	if t.sortCol >= 0 {
+		l.sortCol = t.sortCol + 1
+	}
+	if t.captionAnchor >= 0 {
+		l.captionAnchor = t.captionAnchor + 1
+	}
+	l.colSize = len(l.cs)
+	return l
+}
+
+// Build a copy of the table with aggregate footer cells computed
+// from the rendered rows of their columns
+func (t Table) aggregated() Table {
+	a := t
+	a.cs = copyWidths(t.cs)
+	a.aggAligns = make(map[int]int)
+	a.footers = append([]string{}, t.footers...)
+	for len(a.footers) < len(t.cs) {
+		a.footers = append(a.footers, "")
+	}
+	order := t.rowOrder()
+	for col, fn := range t.aggregates {
+		if col >= len(a.footers) {
+			continue
+		}
+		cells := []string{}
+		for _, n := range order {
+			cells = append(cells, t.rawCell(n, col))
+		}
+		a.footers[col] = fn(cells)
+		if w := DisplayWidth(a.footers[col]); w > a.cs[col] {
+			a.cs[col] = w
+		}
+		a.aggAligns[col] = t.aggAlign
+		if t.aggAlign == ALIGN_DEFAULT {
+			a.aggAligns[col] = t.columnAlign(col)
+		}
+	}
+	a.aggregates = nil
+	return a
+}
+
+// Build a copy of the table with a trailing column holding
+// the row hash of every row
+func (t Table) hashed() Table {
+	h := t
+	h.hashFn = nil
+	h.cs = make(map[int]int)
+	h.rows = [][]string{}
+	h.lines = [][][]string{}
+
+	for i, v := range t.cs {
+		h.cs[i] = v
+	}
+	col := len(t.cs)
+	h.cs[col] = DisplayWidth(t.hashHeader)
+
+	if len(t.headers) > 0 {
+		h.headers = append(append([]string{}, t.headers...), t.hashHeader)
+	}
+	if len(t.footers) > 0 {
+		h.footers = append(append([]string{}, t.footers...), "")
+	}
+	h.src = t.sourceRows()
+	for n, line := range t.lines {
+		sum := ""
+		if n < len(t.rows) {
+			sum = t.hashFn(h.src[n])
+			h.rows = append(h.rows, append(append([]string{}, t.rows[n]...), sum))
+		}
+		if w := DisplayWidth(sum); w > h.cs[col] {
+			h.cs[col] = w
+		}
+		h.lines = append(h.lines, append(append([][]string{}, line...), []string{sum}))
+	}
+	h.colSize = len(h.cs)
+	return h
+}
+
+// Build a copy of the table with display positions as its first column
+// The index header is the row label header unless a label column used it
+func (t Table) indexed() Table {
+	i := t
+	i.labels = make([]string, len(t.lines))
+	for k, n := range t.rowOrder() {
+		i.labels[n] = strconv.Itoa(k + 1)
+	}
+	i.autoIndex = false
+	return i.labelled()
+}
+
+// Build a copy of the table with the column order reversed
+func (t Table) mirrored() Table {
+	n := len(t.cs)
+	cols := make([]int, n)
+	for i := range cols {
+		cols[i] = n - 1 - i
+	}
+	m := t.subTable(cols)
+	m.maxCols = t.maxCols
+	if t.captionAnchor >= 0 && t.captionAnchor < n {
+		m.captionAnchor = n - 1 - t.captionAnchor
+	}
+	return m
+}
+
+// Build a copy of the table without its hidden columns
+func (t Table) visible() Table {
+	cols := []int{}
+	for i := 0; i < len(t.cs); i++ {
+		if !t.hidden[i] {
+			cols = append(cols, i)
+		}
+	}
+	v := t.subTable(cols)
+	v.hidden = make(map[int]bool)
+	v.maxCols = t.maxCols
+	v.captionAnchor = -1
+	for i, c := range cols {
+		if c == t.captionAnchor {
+			v.captionAnchor = i
+		}
+	}
+	return v
 }
 
-func (t *Table) SetBorders(border Border) {
-	t.borders = border
+// Select the given indexes from a slice, ignoring missing ones
+func pick(values []string, cols []int) []string {
+	out := []string{}
+	for _, c := range cols {
+		if c < len(values) {
+			out = append(out, values[c])
+		}
+	}
+	return out
 }
 
-// Append row to table
-func (t *Table) Append(row []string) {
-	rowSize := len(t.headers)
-	if rowSize > t.colSize {
-		t.colSize = rowSize
+// Pick the colors of the given columns, keeping their positions
+func pickColors(colors []Colors, cols []int) []Colors {
+	if len(colors) == 0 {
+		return nil
 	}
+	out := make([]Colors, len(cols))
+	for i, c := range cols {
+		out[i] = colorsAt(colors, c)
+	}
+	return out
+}
 
-	n := len(t.lines)
-	line := [][]string{}
-	for i, v := range row {
+// Set table Data
+// Replaces any existing header, footer and rows with a fresh data set
+func (t *Table) SetData(header []string, rows [][]string) {
+	t.rows = [][]string{}
+	t.lines = [][][]string{}
+	// A frozen layout keeps its widths and rows are fitted into them
+	if t.frozen {
+		t.cs = copyWidths(t.cs)
+	} else {
+		t.cs = make(map[int]int)
+	}
+	t.rs = make(map[int]int)
+	t.split = make(map[int]bool)
+	t.headers = []string{}
+	t.footers = []string{}
+	t.colSize = -1
+	t.SetHeader(header)
+	t.AppendBulk(rows)
+}
 
-		// Detect string  width
-		// Detect String height
-		// Break strings into words
-		out := t.parseDimension(v, i, n)
+// Print line based on row width
+func (t Table) printLine(pos int, nl bool) {
+	t.printRule(pos, t.pCenter, nl)
+}
 
-		// Append broken words
-		line = append(line, out)
+// Print the line between the body and the footer
+// The footer junction replaces the center separator when set
+func (t Table) printFooterLine() {
+	center := t.pCenter
+	if len(t.footers) > 0 && t.fJunction != "" {
+		center = t.fJunction
 	}
-	t.lines = append(t.lines, line)
+	pos := ruleBottom
+	t.mark(LineBottomRule)
+	if len(t.footers) > 0 {
+		pos = ruleMid
+		t.mark(LineFooterRule)
+	}
+	t.printRule(pos, center, true)
 }
 
-// Allow Support for Bulk Append
-// Eliminates repeated for loops
-func (t *Table) AppendBulk(rows [][]string) {
-	for _, row := range rows {
-		t.Append(row)
+// Return the junction glyph after column col of a rule, -1 being the left edge
+// Box styles pick corners and tees by position, a custom junction is kept.
+// Edges without a border continue the rule instead
+func (t Table) junction(pos, col int, center string) string {
+	if t.box == nil || center != t.pCenter {
+		return center
+	}
+	switch {
+	case col < 0:
+		if !t.borders.Left {
+			return t.pRow
+		}
+		return t.box[pos][0]
+	case col >= len(t.cs)-1:
+		if !t.borders.Right {
+			return t.pRow
+		}
+		return t.box[pos][2]
 	}
+	return t.box[pos][1]
 }
 
-// Print line based on row width
-func (t Table) printLine(nl bool) {
-	fmt.Fprint(t.out, t.pCenter)
+// Print line based on row width with the given junction
+func (t Table) printRule(pos int, center string, nl bool) {
+	fmt.Fprint(t.out, t.junction(pos, -1, center))
 	for i := 0; i < len(t.cs); i++ {
 		v := t.cs[i]
+		junction := t.junction(pos, i, center)
+		if i < len(t.cs)-1 && !t.interior {
+			junction = t.pRow
+		}
 		fmt.Fprintf(t.out, "%s%s%s%s",
 			t.pRow,
 			strings.Repeat(string(t.pRow), v),
 			t.pRow,
-			t.pCenter)
+			junction)
 	}
 	if nl {
 		fmt.Fprint(t.out, t.newLine)
@@ -286,6 +2087,7 @@ func (t Table) printHeading() {
 	if len(t.headers) < 1 {
 		return
 	}
+	t.mark(LineHeader)
 
 	// Check if border is set
 	// Replace with space if not set
@@ -297,23 +2099,154 @@ func (t Table) printHeading() {
 	// Get pad function
 	padFunc := pad(t.hAlign)
 
+	// Wrap every header to its column or span
+	lines := make(map[int][]string)
+	height := 0
+	for i := 0; i <= end; i += t.headerSpan(i) {
+		lines[i] = t.wrapHeader(t.headers[i], t.spanWidth(i))
+		if len(lines[i]) > height {
+			height = len(lines[i])
+		}
+	}
+
 	// Print Heading column
-	for i := 0; i <= end; i++ {
-		v := t.cs[i]
-		h := t.headers[i]
-		if t.autoFmt {
-			h = Title(h)
+	for x := 0; x < height; x++ {
+		if x > 0 {
+			fmt.Fprint(t.out, ConditionString(t.borders.Left, t.pColumn, SPACE))
 		}
-		pad := ConditionString((i == end && !t.borders.Left), SPACE, t.pColumn)
-		fmt.Fprintf(t.out, " %s %s",
-			padFunc(h, SPACE, v),
-			pad)
+		for i := 0; i <= end; i += t.headerSpan(i) {
+			v := t.spanWidth(i)
+			cellPad := padFunc
+			if t.hFollow && t.headerSpan(i) == 1 {
+				cellPad = PadRight
+				if align := t.dataAlign(i); align != ALIGN_DEFAULT {
+					cellPad = pad(align)
+				}
+			}
+			h := ""
+			if x < len(lines[i]) {
+				h = lines[i][x]
+			}
+			if t.boldHdr && h != "" {
+				h = format(h, []int{BOLD})
+			}
+			last := i+t.headerSpan(i)-1 == end
+			pad := ConditionString((last && !t.borders.Left), SPACE, t.pColumn)
+			if !last && (!t.hdrSep || !t.interior) {
+				pad = SPACE
+			}
+			fmt.Fprintf(t.out, " %s %s",
+				format(cellPad(h, SPACE, v), colorsAt(t.hColors, i)),
+				pad)
+		}
+		// Next line
+		fmt.Fprint(t.out, t.newLine)
 	}
-	// Next line
-	fmt.Fprint(t.out, t.newLine)
 	if t.hdrLine {
-		t.printLine(true)
+		t.mark(LineHeaderRule)
+		t.printLine(ruleMid, true)
+	}
+	t.mark(LineHeader)
+	for n := 0; n < t.hdrGap; n++ {
+		t.printBlank()
+	}
+}
+
+// Return the number of columns the header of col spans
+func (t Table) headerSpan(col int) int {
+	if n := t.hSpans[col]; n > 1 && col+n <= len(t.cs) {
+		return n
+	}
+	return 1
+}
+
+// Return the width of the header span starting at col
+// Spanned columns also take the padding and separators between them
+func (t Table) spanWidth(col int) int {
+	width := 0
+	for i := col; i < col+t.headerSpan(col); i++ {
+		width += t.cs[i]
+	}
+	return width + 3*(t.headerSpan(col)-1)
+}
+
+// Print the top line, filling junctions inside header spans
+func (t Table) printTop() {
+	t.mark(LineTopRule)
+	if t.boxTitle != "" {
+		var buf bytes.Buffer
+		r := t
+		r.out = &buf
+		r.boxTitle = ""
+		r.printTop()
+		fmt.Fprint(t.out, t.titledRule(strings.TrimSuffix(buf.String(), t.newLine)), t.newLine)
+		return
+	}
+	if len(t.hSpans) == 0 || len(t.headers) == 0 {
+		t.printLine(ruleTop, true)
+		return
+	}
+	fill := t.spanFill
+	if fill == "" {
+		fill = t.pRow
+	}
+	inside := make(map[int]bool)
+	for i := 0; i < len(t.cs); i += t.headerSpan(i) {
+		for j := i; j < i+t.headerSpan(i)-1; j++ {
+			inside[j] = true
+		}
+	}
+	fmt.Fprint(t.out, t.junction(ruleTop, -1, t.pCenter))
+	for i := 0; i < len(t.cs); i++ {
+		junction := t.junction(ruleTop, i, t.pCenter)
+		if inside[i] {
+			junction = fill
+		} else if i < len(t.cs)-1 && !t.interior {
+			junction = t.pRow
+		}
+		fmt.Fprintf(t.out, "%s%s%s%s",
+			t.pRow,
+			strings.Repeat(string(t.pRow), t.cs[i]),
+			t.pRow,
+			junction)
+	}
+	fmt.Fprint(t.out, t.newLine)
+}
+
+// Overlay the box title on the center of a rule, keeping its corners
+// The title is truncated when the rule is too short to hold it
+func (t Table) titledRule(rule string) string {
+	runes := []rune(rule)
+	inner := len(runes) - 2
+	if inner < 3 {
+		return rule
+	}
+	title := SPACE + Truncate(t.boxTitle, inner-2, t.ellipsis) + SPACE
+	width := DisplayWidth(title)
+	start := 1 + (inner-width)/2
+	return string(runes[:start]) + title + string(runes[start+width:])
+}
+
+// Print an empty line keeping the column separators
+func (t Table) printBlank() {
+	for i := 0; i < len(t.cs); i++ {
+		fmt.Fprint(t.out, ConditionString((!t.borders.Left && i == 0) || (!t.interior && i > 0), SPACE, t.pColumn))
+		fmt.Fprint(t.out, strings.Repeat(SPACE, t.cs[i]+2))
+	}
+	fmt.Fprint(t.out, ConditionString(t.borders.Right, t.pColumn, SPACE))
+	fmt.Fprint(t.out, t.newLine)
+}
+
+// Format a footer cell
+// Numbers are kept verbatim so decimal points survive
+func (t Table) footerText(f string) string {
+	if !t.autoFmt || isNumeric(f) {
+		return f
 	}
+	if !t.fAutoFmt {
+		return Normalize(f)
+	}
+	return Title(f)
 }
 
 // Print heading information
@@ -325,8 +2258,9 @@ func (t Table) printFooter() {
 
 	// Only print line if border is not set
 	if !t.borders.Bottom {
-		t.printLine(true)
+		t.printFooterLine()
 	}
+	t.mark(LineFooter)
 	// Check if border is set
 	// Replace with space if not set
 	fmt.Fprint(t.out, ConditionString(t.borders.Bottom, t.pColumn, SPACE))
@@ -340,22 +2274,46 @@ func (t Table) printFooter() {
 	// Print Heading column
 	for i := 0; i <= end; i++ {
 		v := t.cs[i]
-		f := t.footers[i]
-		if t.autoFmt {
-			f = Title(f)
+		f := t.footerText(t.footers[i])
+		if !t.fWidth {
+			f = t.truncateCell(f, v, i)
 		}
 		pad := ConditionString((i == end && !t.borders.Top), SPACE, t.pColumn)
 
-		if len(t.footers[i]) == 0 {
+		if len(t.footers[i]) == 0 || (i < end && !t.interior) {
 			pad = SPACE
 		}
+
+		// Numeric footers may follow the body and align right
+		cellPad := padFunc
+		if t.fAlign == ALIGN_DEFAULT && t.fAutoNum && t.rightAligned(i, f) {
+			cellPad = PadLeft
+		}
+		if align, ok := t.aggAligns[i]; ok {
+			switch {
+			case align == ALIGN_CENTER:
+				cellPad = Pad
+			case align == ALIGN_RIGHT, align == ALIGN_DEFAULT && t.rightAligned(i, f):
+				cellPad = PadLeft
+			default:
+				cellPad = PadRight
+			}
+		}
 		fmt.Fprintf(t.out, " %s %s",
-			padFunc(f, SPACE, v),
+			cellPad(f, SPACE, v),
 			pad)
 	}
 	// Next line
 	fmt.Fprint(t.out, t.newLine)
-	//t.printLine(true)
+
+	t.mark(LineBottomRule)
+	switch t.fRule {
+	case FOOTER_RULE_NONE:
+		return
+	case FOOTER_RULE_FULL:
+		t.printLine(ruleBottom, true)
+		return
+	}
 
 	hasPrinted := false
 
@@ -376,7 +2334,7 @@ func (t Table) printFooter() {
 
 		// Print first junction
 		if i == 0 {
-			fmt.Fprint(t.out, center)
+			fmt.Fprint(t.out, t.junction(ruleBottom, -1, center))
 		}
 
 		// Pad With space of length is 0
@@ -396,12 +2354,16 @@ func (t Table) printFooter() {
 			}
 		}
 
+		if i < end && !t.interior && center == t.pCenter {
+			center = t.pRow
+		}
+
 		// Print the footer
 		fmt.Fprintf(t.out, "%s%s%s%s",
 			pad,
 			strings.Repeat(string(pad), v),
 			pad,
-			center)
+			t.junction(ruleBottom, i, center))
 
 	}
 
@@ -409,13 +2371,282 @@ func (t Table) printFooter() {
 
 }
 
+// Print rows and footer as blocks of labelled fields
+func (t Table) printAccessible() {
+	records := [][][]string{}
+	for _, i := range t.rowOrder() {
+		records = append(records, t.lines[i])
+	}
+	if len(t.footers) > 0 {
+		footer := [][]string{}
+		for _, f := range t.footers {
+			footer = append(footer, []string{f})
+		}
+		records = append(records, footer)
+	}
+
+	for i, columns := range records {
+		if i > 0 {
+			fmt.Fprint(t.out, t.newLine)
+		}
+		for y, cell := range columns {
+			value := strings.Join(cell, SPACE)
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(t.out, "%s: %s%s", t.fieldLabel(y), value, t.newLine)
+		}
+	}
+}
+
+// Return the label of a column for labelled output
+// Falls back to the column number when no header is set
+func (t Table) fieldLabel(col int) string {
+	if col >= len(t.headers) {
+		return fmt.Sprintf("Column %d", col+1)
+	}
+	return t.formatHeader(t.headers[col])
+}
+
+// Format header text based on auto format and header case settings
+// Measure a header so its column fits the header once wrapped
+func (t *Table) parseHeader(str string, colKey int) {
+	t.parseDimension(str, colKey, -1)
+	for _, line := range t.wrapHeader(str, t.cs[colKey]) {
+		if w := DisplayWidth(line); w > t.cs[colKey] {
+			t.cs[colKey] = w
+		}
+	}
+}
+
+// Measure a footer unless footers are kept out of the widths
+func (t *Table) parseFooter(str string, colKey int) {
+	if t.fWidth {
+		t.parseDimension(str, colKey, -1)
+	} else if _, ok := t.cs[colKey]; !ok {
+		t.cs[colKey] = 0
+	}
+}
+
+// Format a header and wrap it to width when auto wrap is on
+// A header only wraps when it is wider than its column
+func (t Table) wrapHeader(str string, width int) []string {
+	h := t.formatHeader(str)
+	if t.autoWrap && DisplayWidth(h) > width {
+		lines, _ := wrapString(h, width, t.smartWrap)
+		return lines
+	}
+	return []string{h}
+}
+
+// Return the number of lines the header takes
+func (t Table) headerHeight() int {
+	height := 0
+	for i, v := range t.headers {
+		if n := len(t.wrapHeader(v, t.cs[i])); n > height {
+			height = n
+		}
+	}
+	return height
+}
+
+func (t Table) formatHeader(h string) string {
+	if !t.autoFmt {
+		return h
+	}
+	switch t.hCase {
+	case CASE_NONE:
+		return Normalize(h)
+	case CASE_LOWER:
+		return mapText(Normalize(h), strings.ToLower)
+	case CASE_TITLE:
+		return TitleCase(Normalize(h))
+	}
+	return Title(h)
+}
+
 // Print caption text
 func (t Table) printCaption() {
-	width := t.getTableWidth()
-	paragraph, _ := WrapString(t.captionText, width)
+	for i := 0; i < t.captionGap; i++ {
+		fmt.Fprint(t.out, t.newLine)
+	}
+	// Anchored captions start at the content of the given column
+	indent := 0
+	if t.captionAnchor > 0 && t.captionAnchor < len(t.cs) {
+		indent = 2
+		for i := 0; i < t.captionAnchor; i++ {
+			indent += t.cs[i] + 3
+		}
+	}
+	width := t.getTableWidth() - indent
+	printLine := func(line string) {
+		switch t.captionAlign {
+		case ALIGN_CENTER:
+			line = strings.TrimRight(Pad(line, SPACE, width), SPACE)
+		case ALIGN_RIGHT:
+			line = PadLeft(line, SPACE, width)
+		}
+		fmt.Fprint(t.out, strings.Repeat(SPACE, indent)+line, t.newLine)
+	}
+
+	// Huge captions are wrapped greedily and written line by line
+	// as the minimal raggedness wrap needs memory quadratic in words
+	text := t.captionText
+	if t.captionLabel != "" {
+		text = t.captionLabel + t.captionSep + text
+	}
+	if len(text) > MAX_CAPTION_BUFFER {
+		wrapStream(text, width, printLine)
+		return
+	}
+	paragraph, _ := WrapString(text, width)
 	for linecount := 0; linecount < len(paragraph); linecount++ {
-		fmt.Fprintln(t.out, paragraph[linecount])
+		printLine(paragraph[linecount])
+	}
+}
+
+// Print legend entries, each wrapped to the table width
+func (t Table) printLegend() {
+	width := t.getTableWidth()
+	for _, entry := range t.legend {
+		lines, _ := WrapString(entry, width)
+		for _, line := range lines {
+			fmt.Fprint(t.out, line, t.newLine)
+		}
+	}
+}
+
+// Return a copy of the column widths keyed by column index
+// Widths exclude the padding and separators around each cell
+func (t *Table) ColumnWidthMap() map[int]int {
+	return copyWidths(t.cs)
+}
+
+// Apply column widths from another table
+// Columns are widened to at least the given widths, never narrowed.
+// Apply each table's map to the other to give both identical widths
+func (t *Table) ApplyColumnWidthMap(m map[int]int) {
+	for i, w := range m {
+		if w > t.cs[i] {
+			t.cs[i] = w
+		}
+	}
+}
+
+// Distribute a total width over the columns
+// Each column gets a wrap width in proportion to its content, but never
+// less than its header or longest word, and widths are recalculated.
+// Maximum widths already set are kept when they are tighter
+func (t *Table) DistributeWidth(total int) {
+	natural := make(map[int]int)
+	min := make(map[int]int)
+	measure := func(row []string, header bool) {
+		for i, v := range row {
+			for _, line := range strings.Split(v, nl) {
+				if w := DisplayWidth(line); w > natural[i] {
+					natural[i] = w
+				}
+				if header && DisplayWidth(line) > min[i] {
+					min[i] = DisplayWidth(line)
+				}
+			}
+			for _, word := range strings.Fields(v) {
+				if w := DisplayWidth(word); w > min[i] {
+					min[i] = w
+				}
+			}
+		}
+	}
+	measure(t.headers, true)
+	measure(t.footers, true)
+	for _, row := range t.rows {
+		measure(row, false)
+	}
+
+	// Columns capped with SetColMaxWidth never need more than their cap
+	n := len(natural)
+	for i := 0; i < n; i++ {
+		if c, ok := t.colMax[i]; ok && c > 0 && c < natural[i] {
+			natural[i] = c
+			if natural[i] < min[i] {
+				natural[i] = min[i]
+			}
+		}
+	}
+
+	avail := total - 3*n - 1
+	sumNatural, sumMin := 0, 0
+	for i := 0; i < n; i++ {
+		sumNatural += natural[i]
+		sumMin += min[i]
+	}
+	if sumNatural <= avail {
+		return
+	}
+
+	extra := avail - sumMin
+	if extra < 0 {
+		extra = 0
+	}
+	slack := sumNatural - sumMin
+	caps := make(map[int]int)
+	for i := 0; i < n; i++ {
+		caps[i] = min[i]
+		if slack > 0 {
+			caps[i] += extra * (natural[i] - min[i]) / slack
+		}
+		avail -= caps[i]
+	}
+	// Hand out what rounding left over
+	for i := 0; i < n && avail > 0; i++ {
+		if caps[i] < natural[i] {
+			caps[i]++
+			avail--
+		}
+	}
+	// Keep any tighter cap set by the user
+	for i, c := range caps {
+		if old, ok := t.colMax[i]; !ok || old <= 0 || c < old {
+			t.colMax[i] = c
+		}
+	}
+	t.Recalculate()
+}
+
+// Return the number of data rows
+func (t *Table) NumLines() int {
+	return len(t.lines)
+}
+
+// Return the number of columns
+// This is the widest of the header, footer and rows
+func (t *Table) ColumnCount() int {
+	if len(t.cs) > t.colSize {
+		return len(t.cs)
 	}
+	return t.colSize
+}
+
+// Return the width the table would have without a maximum column width
+// Cells are only broken on their own newlines, never wrapped
+func (t Table) NaturalWidth() int {
+	widths := make(map[int]int)
+	measure := func(row []string) {
+		for i, v := range row {
+			for _, line := range strings.Split(v, nl) {
+				if w := DisplayWidth(line); w > widths[i] {
+					widths[i] = w
+				}
+			}
+		}
+	}
+	measure(t.headers)
+	measure(t.footers)
+	for _, row := range t.rows {
+		measure(row)
+	}
+	t.cs = widths
+	return t.getTableWidth()
 }
 
 // Calculate the total number of characters in a row
@@ -426,24 +2657,135 @@ func (t Table) getTableWidth() int {
 	}
 
 	// Add chars, spaces, seperators to calculate the total width of the table.
-	// ncols := t.colSize
+	// ncols := len(t.cs)
 	// spaces := ncols * 2
 	// seps := ncols + 1
 
-	return (chars + (3 * t.colSize) + 2)
+	return (chars + (3 * len(t.cs)) + 1)
+}
+
+// Print the empty table text centered across all columns
+func (t Table) printEmpty() {
+	t.printNote(t.emptyText)
+}
+
+// Print a message centered across the full table width
+// Messages wider than the table are truncated
+func (t Table) printNote(text string) {
+	width := t.getTableWidth() - 4
+	fmt.Fprintf(t.out, "%s %s %s%s",
+		ConditionString(t.borders.Left, t.pColumn, SPACE),
+		Pad(Truncate(text, width, t.ellipsis), SPACE, width),
+		ConditionString(t.borders.Right, t.pColumn, SPACE),
+		t.newLine)
 }
 
 func (t Table) printRows() {
-	for i, lines := range t.lines {
-		t.printRow(lines, i)
+	order := t.rowOrder()
+	hidden := 0
+	if t.maxRows > 0 && len(order) > t.maxRows {
+		hidden = len(order) - t.maxRows
+		order = order[:t.maxRows]
+	}
+	merged := t.mergedCells(order)
+	for n, i := range order {
+		columns := t.lines[i]
+		if merged[n] != nil {
+			columns = make([][]string, len(t.lines[i]))
+			for y, cell := range t.lines[i] {
+				columns[y] = cell
+				if merged[n][y] {
+					columns[y] = []string{""}
+				}
+			}
+		}
+		t.mark(LineRow)
+		t.printRow(columns, i, n)
+		// Row lines go between rows, the bottom border closes the last one
+		if !t.rowLine || (n == len(order)-1 && hidden == 0) {
+			continue
+		}
+		t.mark(LineRowRule)
+		if n < len(order)-1 && merged[n+1] != nil {
+			t.printMergeLine(merged[n+1])
+		} else {
+			t.printLine(ruleMid, true)
+		}
+	}
+	if hidden > 0 {
+		t.mark(LineRow)
+		t.printNote(t.truncationMessage(hidden))
+	}
+}
+
+// Return the cells of each rendered row that repeat the cell above
+// Rows without any merged cell are nil
+func (t Table) mergedCells(order []int) [][]bool {
+	merged := make([][]bool, len(order))
+	if !t.autoMerge {
+		return merged
+	}
+	for n := 1; n < len(order); n++ {
+		above, row := t.lines[order[n-1]], t.lines[order[n]]
+		for y := range row {
+			text := strings.Join(row[y], nl)
+			if y >= len(above) || strings.TrimSpace(text) == "" || text != strings.Join(above[y], nl) {
+				continue
+			}
+			if merged[n] == nil {
+				merged[n] = make([]bool, len(row))
+			}
+			merged[n][y] = true
+		}
+	}
+	return merged
+}
+
+// Print a row line that leaves out the columns merged into the row above
+func (t Table) printMergeLine(merged []bool) {
+	end := len(t.cs) - 1
+	isMerged := func(col int) bool {
+		return col < len(merged) && merged[col]
+	}
+	fmt.Fprint(t.out, ConditionString(isMerged(0), t.pColumn, t.junction(ruleMid, -1, t.pCenter)))
+	for i := 0; i <= end; i++ {
+		fill := t.pRow
+		if isMerged(i) {
+			fill = SPACE
+		}
+		junction := t.junction(ruleMid, i, t.pCenter)
+		switch {
+		case i == end:
+			if isMerged(i) {
+				junction = t.pColumn
+			}
+		case isMerged(i) && isMerged(i+1):
+			junction = ConditionString(t.interior, t.pColumn, SPACE)
+		case !t.interior:
+			junction = t.pRow
+		case t.box != nil && isMerged(i):
+			junction = t.box[ruleMid][0]
+		case t.box != nil && isMerged(i+1):
+			junction = t.box[ruleMid][2]
+		}
+		fmt.Fprint(t.out, strings.Repeat(fill, t.cs[i]+2), junction)
 	}
+	fmt.Fprint(t.out, t.newLine)
+}
 
+// Describe rows hidden by the maximum row count
+func (t Table) truncationMessage(hidden int) string {
+	if t.truncFmt != nil {
+		return t.truncFmt(hidden)
+	}
+	return fmt.Sprintf("%s (%d more rows)", ELLIPSIS, hidden)
 }
 
 // Print Row Information
 // Adjust column alignment based on type
+// n is the position of the row in the output, used for striping
 
-func (t Table) printRow(columns [][]string, colKey int) {
+func (t Table) printRow(columns [][]string, colKey, n int) {
 	// Get Maximum Height
 	max := t.rs[colKey]
 	total := len(columns)
@@ -460,48 +2802,67 @@ func (t Table) printRow(columns [][]string, colKey int) {
 	// pads := []int{}
 	pads := []int{}
 
+	// Short cells are padded above, around or below by vertical alignment
+	padded := make([][]string, total)
 	for i, line := range columns {
 		length := len(line)
 		pad := max - length
 		pads = append(pads, pad)
-		for n := 0; n < pad; n++ {
-			columns[i] = append(columns[i], "  ")
+		before := 0
+		switch t.vAlign {
+		case VALIGN_MIDDLE:
+			before = pad / 2
+		case VALIGN_BOTTOM:
+			before = pad
+		}
+		for k := 0; k < before; k++ {
+			padded[i] = append(padded[i], "  ")
+		}
+		padded[i] = append(padded[i], line...)
+		for k := before; k < pad; k++ {
+			padded[i] = append(padded[i], "  ")
 		}
 	}
+	columns = padded
 	//fmt.Println(max, "\n")
 	for x := 0; x < max; x++ {
 		for y := 0; y < total; y++ {
 
 			// Check if border is set
-			fmt.Fprint(t.out, ConditionString((!t.borders.Left && y == 0), SPACE, t.pColumn))
+			fmt.Fprint(t.out, ConditionString((!t.borders.Left && y == 0) || (!t.interior && y > 0), SPACE, t.pColumn))
 
 			fmt.Fprintf(t.out, SPACE)
 			str := columns[y][x]
 
 			// This would print alignment
 			// Default alignment  would use multiple configuration
-			switch t.align {
+			switch t.columnAlign(y) {
 			case ALIGN_CENTER: //
-				fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
+				str = Pad(str, SPACE, t.cs[y])
 			case ALIGN_RIGHT:
-				fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
+				str = PadLeft(str, SPACE, t.cs[y])
 			case ALIGN_LEFT:
-				fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
+				str = PadRight(str, SPACE, t.cs[y])
 			default:
-				if decimal.MatchString(strings.TrimSpace(str)) || percent.MatchString(strings.TrimSpace(str)) {
-					fmt.Fprintf(t.out, "%s", PadLeft(str, SPACE, t.cs[y]))
+				if d, ok := t.decimals[y]; ok {
+					str = PadLeft(t.alignAt(y, str, d), SPACE, t.cs[y])
+				} else if t.rightAligned(y, str) {
+					str = PadLeft(str, SPACE, t.cs[y])
 				} else {
-					fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-
-					// TODO Custom alignment per column
-					//if max == 1 || pads[y] > 0 {
-					//	fmt.Fprintf(t.out, "%s", Pad(str, SPACE, t.cs[y]))
-					//} else {
-					//	fmt.Fprintf(t.out, "%s", PadRight(str, SPACE, t.cs[y]))
-					//}
-
+					str = PadRight(str, SPACE, t.cs[y])
 				}
 			}
+
+			// Color the padded cell so backgrounds fill the column
+			if colors, ok := t.rowColors[colKey]; ok {
+				str = format(str, colors)
+			} else if colors := t.categoryColors(colKey); colors != nil {
+				str = format(str, colors)
+			} else {
+				colors := append(Colors{}, colorsAt(t.colColors, y)...)
+				str = format(str, append(colors, t.stripes[n%2]...))
+			}
+			fmt.Fprintf(t.out, "%s", str)
 			fmt.Fprintf(t.out, SPACE)
 		}
 		// Check if border is set
@@ -509,11 +2870,55 @@ func (t Table) printRow(columns [][]string, colKey int) {
 		fmt.Fprint(t.out, ConditionString(t.borders.Left, t.pColumn, SPACE))
 		fmt.Fprint(t.out, t.newLine)
 	}
+}
+
+// Return the alignment of the data cells of a column
+func (t Table) columnAlign(col int) int {
+	if col < len(t.colAlign) && t.colAlign[col] != ALIGN_DEFAULT {
+		return t.colAlign[col]
+	}
+	return t.align
+}
 
-	if t.rowLine {
-		t.printLine(true)
+// Return the effective alignment of the data of a column
+// Default columns are aligned right when every cell is numeric
+func (t Table) dataAlign(col int) int {
+	if align := t.columnAlign(col); align != ALIGN_DEFAULT {
+		return align
+	}
+	// Rendered cells already follow hidden, mirrored and label columns
+	numeric := false
+	for n := range t.lines {
+		text := strings.TrimSpace(t.cellText(n, col))
+		if text == "" {
+			continue
+		}
+		if !t.rightAligned(col, text) {
+			return ALIGN_DEFAULT
+		}
+		numeric = true
 	}
+	if numeric {
+		return ALIGN_RIGHT
+	}
+	return ALIGN_DEFAULT
+}
 
+// Check if a cell looks like a number, percentage or currency amount
+// Such cells are right aligned by default
+func isNumeric(str string) bool {
+	str = strings.TrimSpace(str)
+	return decimal.MatchString(str) || percent.MatchString(str) || currency.MatchString(str) ||
+		parens.MatchString(str)
+}
+
+// Check if a cell is right aligned under default alignment
+// A declared column type takes precedence over the cell content
+func (t Table) rightAligned(col int, str string) bool {
+	if typ, ok := t.colTypes[col]; ok {
+		return typ == ColumnNumber || typ == ColumnDecimal
+	}
+	return isNumeric(str)
 }
 
 func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
@@ -521,16 +2926,31 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
 		raw []string
 		max int
 	)
+	// Whitespace only cells never widen their column unless kept verbatim
+	blank := t.trimBlank && rowKey != -1 && strings.TrimSpace(str) == ""
+	if blank {
+		str = strings.Repeat(nl, strings.Count(str, nl))
+	}
 	w := DisplayWidth(str)
 	// Calculate Width
 	// Check if with is grater than maximum width
-	if w > t.mW {
-		w = t.mW
+	// A maximum width of 0 or less means unlimited
+	limit := t.mW
+	if v, ok := t.colMax[colKey]; ok {
+		limit = v
+	}
+	if limit > 0 && w > limit {
+		w = limit
+	}
+	// The minimum width wins over the maximum
+	if min := t.colMin[colKey]; w < min {
+		w = min
 	}
 
 	// Check if width exists
 	v, ok := t.cs[colKey]
-	if !ok || v < w || v == 0 {
+	frozen := t.frozen && ok
+	if !frozen && (!ok || v < w || v == 0) {
 		t.cs[colKey] = w
 	}
 
@@ -538,13 +2958,37 @@ func (t *Table) parseDimension(str string, colKey, rowKey int) []string {
 		return raw
 	}
 	// Calculate Height
-	if t.autoWrap {
-		raw, _ = WrapString(str, t.cs[colKey])
+	wrap := t.autoWrap
+	if v, ok := t.cellWrap[cellKey{rowKey, colKey}]; ok {
+		wrap = v
+	}
+	if blank {
+		raw = []string{""}
+		if t.keepBlank {
+			raw = strings.Split(str, nl)
+		}
+	} else if t.truncated(colKey) {
+		for _, line := range getLines(str) {
+			raw = append(raw, t.truncateCell(line, t.cs[colKey], colKey))
+		}
+	} else if wrap && limit > 0 {
+		raw, _ = wrapString(str, t.cs[colKey], t.smartWrap)
 	} else {
 		raw = getLines(str)
 	}
 
-	for _, line := range raw {
+	// Cap the height and mark the cut on the last visible line
+	if t.maxHeight > 0 && len(raw) > t.maxHeight {
+		raw = raw[:t.maxHeight]
+		last := raw[t.maxHeight-1] + t.ellipsis
+		raw[t.maxHeight-1] = Truncate(last, t.cs[colKey], t.ellipsis)
+	}
+
+	for i, line := range raw {
+		if frozen {
+			line = t.truncateCell(line, t.cs[colKey], colKey)
+			raw[i] = line
+		}
 		if w := DisplayWidth(line); w > max {
 			max = w
 		}
@@ -9,9 +9,15 @@ package tablewriter
 
 import (
 	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/csv"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -87,6 +93,142 @@ func TestCSVSeparator(t *testing.T) {
 	table.Render()
 }
 
+func TestExportNewlines(t *testing.T) {
+	table := NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Notes"})
+	table.Append([]string{"a", "line one\nline two"})
+
+	var buf bytes.Buffer
+	if err := table.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "Name,Notes\na,\"line one\nline two\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("csv export failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+
+	buf.Reset()
+	if err := table.WriteTSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want = "Name\tNotes\na\tline one line two\n"
+	if got := buf.String(); got != want {
+		t.Errorf("tsv export failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+
+	buf.Reset()
+	table.SetExportNewlineMode(EXPORT_NEWLINE_SPACE)
+	if err := table.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want = "Name,Notes\na,line one line two\n"
+	if got := buf.String(); got != want {
+		t.Errorf("csv export failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+}
+
+func TestRenderCSVStream(t *testing.T) {
+	input := `name,note
+a,shortish
+b,tiny
+c,a longer note here
+`
+	var buf bytes.Buffer
+	err := RenderCSVStream(&buf, strings.NewReader(input), CSVOptions{HasHeader: true, SampleRows: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `+------+----------+
+| NAME |   NOTE   |
++------+----------+
+| a    | shortish |
+| b    | tiny     |
+| c    | a longer |
+|      | note     |
+|      | here     |
++------+----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("csv stream rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderCSVStreamWideRows(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderCSVStream(&buf, strings.NewReader("a,b\n1,2\n"), CSVOptions{HasHeader: true, Widths: []int{3, 3, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `+-----+-----+-----+
+|  A  |  B  |     |
++-----+-----+-----+
+|   1 |   2 |     |
++-----+-----+-----+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("csv stream rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	err = RenderCSVStream(&buf, strings.NewReader("a,b\n1,2,3\n4,5\n"), CSVOptions{HasHeader: true, SampleRows: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `+---+---+---+
+| A | B |   |
++---+---+---+
+| 1 | 2 | 3 |
+| 4 | 5 |   |
++---+---+---+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("csv stream rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderCSVStreamEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderCSVStream(&buf, strings.NewReader(""), CSVOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("empty csv stream Wants: %q Got: %q", "", buf.String())
+	}
+}
+
+func TestRenderCSVStreamWideCell(t *testing.T) {
+	input := "id,note\n1,ok\n2,long values here\n"
+	var buf bytes.Buffer
+	err := RenderCSVStream(&buf, strings.NewReader(input), CSVOptions{HasHeader: true, Widths: []int{2, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `+----+------+
+| ID | NOTE |
++----+------+
+|  1 | ok   |
+|  2 | lon… |
++----+------+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("csv stream rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderCSVStreamMemory(t *testing.T) {
+	input := "n\n" + strings.Repeat("row\n", 50)
+	table := NewWriter(&bytes.Buffer{})
+	reader := csv.NewReader(strings.NewReader(input))
+	if err := table.renderCSVStream(reader, CSVOptions{HasHeader: true, SampleRows: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if len(table.rows) > 2 || len(table.lines) > 2 {
+		t.Errorf("csv stream kept %d rows and %d lines, want at most 2", len(table.rows), len(table.lines))
+	}
+}
+
 func TestNoBorder(t *testing.T) {
 	data := [][]string{
 		[]string{"1/1/2014", "Domain name", "2233", "$10.98"},
@@ -105,12 +247,12 @@ func TestNoBorder(t *testing.T) {
 
 	want := `    DATE   |       DESCRIPTION        |  CV2  | AMOUNT   
 +----------+--------------------------+-------+---------+
-  1/1/2014 | Domain name              |  2233 | $10.98   
-  1/1/2014 | January Hosting          |  2233 | $54.95   
-  1/4/2014 | February Hosting         |  2233 | $51.00   
-  1/4/2014 | February Extra Bandwidth |  2233 | $30.00   
+  1/1/2014 | Domain name              |  2233 |  $10.98  
+  1/1/2014 | January Hosting          |  2233 |  $54.95  
+  1/4/2014 | February Hosting         |  2233 |  $51.00  
+  1/4/2014 | February Extra Bandwidth |  2233 |  $30.00  
 +----------+--------------------------+-------+---------+
-                                        TOTAL | $146 93  
+                                        TOTAL | $146.93  
                                       +-------+---------+
 `
 	got := buf.String()
@@ -119,6 +261,40 @@ func TestNoBorder(t *testing.T) {
 	}
 }
 
+func TestFooterRuleMode(t *testing.T) {
+	body := `    DATE   |   DESCRIPTION    |  CV2  | AMOUNT   
++----------+------------------+-------+---------+
+  1/1/2014 | Domain name      |  2233 |  $10.98  
+  1/4/2014 | February Hosting |  2233 |  $51.00  
++----------+------------------+-------+---------+
+                                TOTAL | $146.93  
+`
+	tests := []struct {
+		mode int
+		want string
+	}{
+		{FOOTER_RULE_MINIMAL, body + "                              +-------+---------+\n"},
+		{FOOTER_RULE_FULL, body + "+----------+------------------+-------+---------+\n"},
+		{FOOTER_RULE_NONE, body},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		table := NewWriter(&buf)
+		table.SetHeader([]string{"Date", "Description", "CV2", "Amount"})
+		table.SetFooter([]string{"", "", "Total", "$146.93"})
+		table.SetBorder(false)
+		table.SetFooterRuleMode(test.mode)
+		table.Append([]string{"1/1/2014", "Domain name", "2233", "$10.98"})
+		table.Append([]string{"1/4/2014", "February Hosting", "2233", "$51.00"})
+		table.Render()
+
+		got := buf.String()
+		if got != test.want {
+			t.Errorf("footer rule mode %d rendering failed\ngot:\n%s\nwant:\n%s\n", test.mode, got, test.want)
+		}
+	}
+}
+
 func TestWithBorder(t *testing.T) {
 	data := [][]string{
 		[]string{"1/1/2014", "Domain name", "2233", "$10.98"},
@@ -137,12 +313,12 @@ func TestWithBorder(t *testing.T) {
 	want := `+----------+--------------------------+-------+---------+
 |   DATE   |       DESCRIPTION        |  CV2  | AMOUNT  |
 +----------+--------------------------+-------+---------+
-| 1/1/2014 | Domain name              |  2233 | $10.98  |
-| 1/1/2014 | January Hosting          |  2233 | $54.95  |
-| 1/4/2014 | February Hosting         |  2233 | $51.00  |
-| 1/4/2014 | February Extra Bandwidth |  2233 | $30.00  |
+| 1/1/2014 | Domain name              |  2233 |  $10.98 |
+| 1/1/2014 | January Hosting          |  2233 |  $54.95 |
+| 1/4/2014 | February Hosting         |  2233 |  $51.00 |
+| 1/4/2014 | February Extra Bandwidth |  2233 |  $30.00 |
 +----------+--------------------------+-------+---------+
-|                                       TOTAL | $146 93 |
+|                                       TOTAL | $146.93 |
 +----------+--------------------------+-------+---------+
 `
 	got := buf.String()
@@ -151,6 +327,56 @@ func TestWithBorder(t *testing.T) {
 	}
 }
 
+func TestFooterJunction(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Amount"})
+	table.SetFooter([]string{"Total", "30"})
+	table.SetFooterJunction("#")
+	table.Append([]string{"Apples", "10"})
+	table.Append([]string{"Pears", "20"})
+	table.Render()
+
+	want := `+--------+--------+
+|  ITEM  | AMOUNT |
++--------+--------+
+| Apples |     10 |
+| Pears  |     20 |
+#--------#--------#
+| TOTAL  |   30   |
++--------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("footer junction rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestFooterAutoNumericAlign(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Amount"})
+	table.SetFooter([]string{"Total", "30"})
+	table.SetFooterAutoNumericAlign(true)
+	table.Append([]string{"Apples", "1000"})
+	table.Append([]string{"Pears", "20"})
+	table.Render()
+
+	want := `+--------+--------+
+|  ITEM  | AMOUNT |
++--------+--------+
+| Apples |   1000 |
+| Pears  |     20 |
++--------+--------+
+| TOTAL  |     30 |
++--------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("footer alignment rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
 func TestPrintingInMarkdown(t *testing.T) {
 	fmt.Println("TESTING")
 	data := [][]string{
@@ -210,6 +436,36 @@ func TestPrintHeadingWithoutAutoFormat(t *testing.T) {
 	}
 }
 
+func TestPrintHeadingLowerCase(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"First_Name", "Last.Name", "SSN"})
+	table.SetHeaderCase(CASE_LOWER)
+	table.SetHeaderLine(true)
+	table.printHeading()
+	want := `| first name | last name | ssn |
++------------+-----------+-----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("header rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestPrintHeadingBold(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.SetBoldHeaders(true)
+	table.printHeading()
+	want := "| \033[1mNAME\033[0m | \033[1mRATING\033[0m |\n" +
+		"+------+--------+\n"
+	got := buf.String()
+	if got != want {
+		t.Errorf("bold header rendering failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+}
+
 func TestPrintFooter(t *testing.T) {
 	var buf bytes.Buffer
 	table := NewWriter(&buf)
@@ -275,6 +531,77 @@ Short caption.
 	}
 }
 
+func TestPrintCaptionWithGap(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	table.SetCaption(true, "Short caption.")
+	table.SetCaptionGap(1)
+	table.Render()
+
+	want := `+------+--------+
+| NAME | RATING |
++------+--------+
+| A    |    500 |
++------+--------+
+
+Short caption.
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("caption gap rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestPrintAnchoredCaption(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Very very Bad Man", "288*"})
+	table.SetCaption(true, "* Rating adjusted for inflation.")
+	table.SetCaptionAnchor(2)
+	table.Render()
+
+	want := `+------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| A    | The Very very Bad Man | 288*   |
++------+-----------------------+--------+
+                                 * Rating
+                                 adjusted
+                                 for
+                                 inflation.
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("anchored caption rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestPrintCaptionLabel(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.SetCaption(true, "Movie ratings.")
+	table.SetCaptionLabel("Table 1")
+	table.SetCaptionLabelSeparator(" - ")
+	table.Render()
+
+	want := `+------+----------+--------+
+| NAME |   SIGN   | RATING |
++------+----------+--------+
+| A    | The Good |    500 |
++------+----------+--------+
+Table 1 - Movie ratings.
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("caption label rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
 func TestPrintLongCaptionWithShortExample(t *testing.T) {
 	var buf bytes.Buffer
 	data := [][]string{
@@ -330,12 +657,12 @@ func TestPrintCaptionWithFooter(t *testing.T) {
 
 	want := `    DATE   |       DESCRIPTION        |  CV2  | AMOUNT   
 +----------+--------------------------+-------+---------+
-  1/1/2014 | Domain name              |  2233 | $10.98   
-  1/1/2014 | January Hosting          |  2233 | $54.95   
-  1/4/2014 | February Hosting         |  2233 | $51.00   
-  1/4/2014 | February Extra Bandwidth |  2233 | $30.00   
+  1/1/2014 | Domain name              |  2233 |  $10.98  
+  1/1/2014 | January Hosting          |  2233 |  $54.95  
+  1/4/2014 | February Hosting         |  2233 |  $51.00  
+  1/4/2014 | February Extra Bandwidth |  2233 |  $30.00  
 +----------+--------------------------+-------+---------+
-                                        TOTAL | $146 93  
+                                        TOTAL | $146.93  
                                       +-------+---------+
 This is a very long caption. The text should wrap to the
 width of the table.
@@ -380,6 +707,36 @@ solved.
 	}
 }
 
+type countingWriter struct {
+	writes, lines, longest int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	w.lines += bytes.Count(p, []byte("\n"))
+	if len(p) > w.longest {
+		w.longest = len(p)
+	}
+	return len(p), nil
+}
+
+func TestPrintHugeCaption(t *testing.T) {
+	out := &countingWriter{}
+	table := NewWriter(out)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.SetCaption(true, strings.Repeat("gopher ", 300000))
+	table.Render()
+
+	// The table is 28 columns wide which fits four six letter words
+	if want := 5 + 300000/4; out.lines != want {
+		t.Errorf("huge caption rendered %d lines, want %d", out.lines, want)
+	}
+	if out.longest > 29 {
+		t.Errorf("huge caption was written in chunks of %d bytes, want at most one line", out.longest)
+	}
+}
+
 func TestPrintTableWithAndWithoutAutoWrap(t *testing.T) {
 	var buf bytes.Buffer
 	var multiline = `A multiline
@@ -427,7 +784,7 @@ func TestPrintLine(t *testing.T) {
 	var buf bytes.Buffer
 	table := NewWriter(&buf)
 	table.SetHeader(header)
-	table.printLine(false)
+	table.printLine(ruleMid, false)
 	got := buf.String()
 	if got != want {
 		t.Errorf("line rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
@@ -447,13 +804,37 @@ func TestAnsiStrip(t *testing.T) {
 	var buf bytes.Buffer
 	table := NewWriter(&buf)
 	table.SetHeader(header)
-	table.printLine(false)
+	table.printLine(ruleMid, false)
 	got := buf.String()
 	if got != want {
 		t.Errorf("line rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
 	}
 }
 
+func TestColoredSeparators(t *testing.T) {
+	var plain, colored bytes.Buffer
+	for _, out := range []*bytes.Buffer{&plain, &colored} {
+		table := NewWriter(out)
+		if out == &colored {
+			table.SetColumnSeparator("\033[34m|\033[0m")
+			table.SetRowSeparator("\033[34m-\033[0m")
+			table.SetCenterSeparator("\033[34m+\033[0m")
+		}
+		table.SetHeader([]string{"Name", "Sign", "Rating"})
+		table.Append([]string{"A", "The Good", "500"})
+		table.Append([]string{"B", "The Very very Bad Man", "288"})
+		table.Render()
+	}
+
+	if !strings.Contains(colored.String(), "\033[34m|\033[0m") {
+		t.Errorf("colored separators missing from output:\n%q", colored.String())
+	}
+	got := ansi.ReplaceAllLiteralString(colored.String(), "")
+	if got != plain.String() {
+		t.Errorf("colored separators misaligned columns\ngot:\n%s\nwant:\n%s\n", got, plain.String())
+	}
+}
+
 func NewCustomizedTable(out io.Writer) *Table {
 	table := NewWriter(out)
 	table.SetCenterSeparator("")
@@ -491,3 +872,2853 @@ func TestSubclass(t *testing.T) {
 		t.Error(fmt.Sprintf("Unexpected output '%v' != '%v'", output, want))
 	}
 }
+
+func TestCurrencyAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.Append([]string{"Widget", "$1,234.50"})
+	table.Append([]string{"Gadget", "$99.00"})
+	table.Render()
+
+	want := `+--------+-----------+
+| Widget | $1,234.50 |
+| Gadget |    $99.00 |
++--------+-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("currency alignment failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMaxColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"ID", "A", "B", "C", "D", "E"})
+	table.Append([]string{"1", "a1", "b1", "c1", "d1", "e1"})
+	table.Append([]string{"2", "a2", "b2", "c2", "d2", "e2"})
+	table.SetMaxColumns(3)
+	table.Render()
+
+	want := `+----+----+----+
+| ID | A  | B  |
++----+----+----+
+|  1 | a1 | b1 |
+|  2 | a2 | b2 |
++----+----+----+
+
++----+----+----+
+| ID | C  | D  |
++----+----+----+
+|  1 | c1 | d1 |
+|  2 | c2 | d2 |
++----+----+----+
+
++----+----+
+| ID | E  |
++----+----+
+|  1 | e1 |
+|  2 | e2 |
++----+----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("max columns rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMaxColumnsHideColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"ID", "A", "B", "C", "D"})
+	table.Append([]string{"1", "a1", "b1", "c1", "d1"})
+	table.HideColumn(1)
+	table.SetMaxColumns(3)
+	table.Render()
+
+	want := `+----+----+----+
+| ID | B  | C  |
++----+----+----+
+|  1 | b1 | c1 |
++----+----+----+
+
++----+----+
+| ID | D  |
++----+----+
+|  1 | d1 |
++----+----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("hidden column blocks rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestSetData(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Description"})
+	table.Append([]string{"Old", "A much longer description"})
+	table.SetData([]string{"Key", "Value"}, [][]string{
+		[]string{"a", "1"},
+		[]string{"b", "2"},
+	})
+	table.Render()
+
+	want := `+-----+-------+
+| KEY | VALUE |
++-----+-------+
+| a   |     1 |
+| b   |     2 |
++-----+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("set data rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestPrintRightAlignedCaption(t *testing.T) {
+	var buf bytes.Buffer
+	data := [][]string{
+		[]string{"A", "The Good", "500"},
+		[]string{"B", "The Very very Bad Man", "288"},
+	}
+
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.SetCaption(true, "This caption is anchored to the right edge of the table and wraps.")
+	table.SetCaptionAlignment(ALIGN_RIGHT)
+	table.AppendBulk(data)
+	table.Render()
+
+	want := `+------+-----------------------+--------+
+| NAME |         SIGN          | RATING |
++------+-----------------------+--------+
+| A    | The Good              |    500 |
+| B    | The Very very Bad Man |    288 |
++------+-----------------------+--------+
+    This caption is anchored to the right
+             edge of the table and wraps.
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("right aligned caption rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestSkipEmptyRows(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetSkipEmptyRows(true)
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"", "", ""})
+	table.Append([]string{"B", "The Bad", "288"})
+	table.Render()
+
+	want := `+---+----------+-----+
+| A | The Good | 500 |
+| B | The Bad  | 288 |
++---+----------+-----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("empty row skipping failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestAccessibleStyle(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.SetFooter([]string{"", "Total", "788"})
+	table.SetStyle(StyleAccessible)
+	table.Render()
+
+	want := `NAME: A
+SIGN: The Good
+RATING: 500
+
+NAME: B
+SIGN: The Very very Bad Man
+RATING: 288
+
+SIGN: Total
+RATING: 788
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("accessible rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestTruncateWithEllipsis(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetAutoTruncate(true)
+	table.SetEllipsis("...")
+	table.SetColWidth(10)
+	table.Append([]string{"A", "The quick brown fox jumps"})
+	table.Append([]string{"B", "Short"})
+	table.Render()
+
+	want := `+---+------------+
+| A | The qui... |
+| B | Short      |
++---+------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("truncation rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestSortByNumberColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Count"})
+	table.AppendBulk([][]string{
+		[]string{"a", "10"},
+		[]string{"b", "9"},
+		[]string{"c", "1,200"},
+	})
+	table.SetColumnType(1, ColumnNumber)
+	table.SortByColumn(1, false)
+	table.Render()
+
+	want := `+------+-------+
+| NAME | COUNT |
++------+-------+
+| b    |     9 |
+| a    |    10 |
+| c    | 1,200 |
++------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("numeric sort rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestOriginalRowIndex(t *testing.T) {
+	table := NewWriter(os.Stdout)
+	table.AppendBulk([][]string{
+		[]string{"c", "3"},
+		[]string{"a", "1"},
+		[]string{"b", "2"},
+	})
+	table.SortByColumn(0, false)
+
+	for display, want := range []int{1, 2, 0} {
+		if got := table.OriginalRowIndex(display); got != want {
+			t.Errorf("display row %d maps to %d, want %d", display, got, want)
+		}
+	}
+	if got := table.OriginalRowIndex(3); got != -1 {
+		t.Errorf("out of range display row maps to %d, want -1", got)
+	}
+}
+
+func TestSortByDateColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Event", "Date"})
+	table.AppendBulk([][]string{
+		[]string{"launch", "Mar 3, 2014"},
+		[]string{"beta", "Dec 24, 2013"},
+		[]string{"alpha", "Feb 1, 2013"},
+	})
+	table.SetColumnType(1, ColumnDate)
+	table.SetTimeLayout("Jan 2, 2006")
+	table.SortByColumn(1, true)
+	table.Render()
+
+	want := `+--------+--------------+
+| EVENT  |     DATE     |
++--------+--------------+
+| launch | Mar 3, 2014  |
+| beta   | Dec 24, 2013 |
+| alpha  | Feb 1, 2013  |
++--------+--------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("date sort rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestAppendMultiline(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Address"})
+	table.AppendMultiline([][]string{
+		[]string{"Gopher"},
+		[]string{"1 Main Street", "Suite 100, Floor 2", "Springfield"},
+	})
+	table.Render()
+
+	want := `+--------+--------------------+
+|  NAME  |      ADDRESS       |
++--------+--------------------+
+| Gopher | 1 Main Street      |
+|        | Suite 100, Floor 2 |
+|        | Springfield        |
++--------+--------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("multiline append rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestUnlimitedColWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(0)
+	table.Append([]string{"A", "This sentence is much longer than the default column width"})
+	table.Render()
+
+	want := `+---+------------------------------------------------------------+
+| A | This sentence is much longer than the default column width |
++---+------------------------------------------------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("unlimited width rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRecalculate(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(10)
+	table.Append([]string{"A", "The quick brown fox"})
+	table.SetColWidth(40)
+	table.Recalculate()
+	table.Render()
+
+	want := `+---+---------------------+
+| A | The quick brown fox |
++---+---------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("recalculated rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	var colored, plain bytes.Buffer
+	table := NewWriter(&colored)
+	table.SetHeader([]string{"Name", "Status"})
+	table.Append([]string{"api", "\033[32mOK\033[0m"})
+	table.Append([]string{"db", "\033[31mFAIL\033[0m"})
+	table.Render()
+	if err := table.RenderPlain(&plain); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `+------+--------+
+| NAME | STATUS |
++------+--------+
+| api  | OK     |
+| db   | FAIL   |
++------+--------+
+`
+	got := plain.String()
+	if got != want {
+		t.Errorf("plain rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+	if !strings.Contains(colored.String(), "\033[32mOK") {
+		t.Errorf("colored rendering lost its escapes:\n%q", colored.String())
+	}
+}
+
+func TestCellBrackets(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+	table.Append([]string{"B", "The Bad"})
+	table.SetCellBrackets(1, 1, "[", "]")
+	table.Render()
+
+	want := `+------+-----------+
+| NAME |   SIGN    |
++------+-----------+
+| A    | The Good  |
+| B    | [The Bad] |
++------+-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("cell brackets rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRowColor(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.Append([]string{"A", "The Good"})
+	table.Append([]string{"B", "The Bad"})
+	table.SetRowColor(1, 1, 31)
+	table.Render()
+
+	want := "+---+----------+\n" +
+		"| A | The Good |\n" +
+		"| \033[1;31mB\033[0m | \033[1;31mThe Bad \033[0m |\n" +
+		"+---+----------+\n"
+	got := buf.String()
+	if got != want {
+		t.Errorf("row color rendering failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+}
+
+func TestAccountingNegatives(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Account", "Balance"})
+	table.Append([]string{"Cash", "5000.00"})
+	table.Append([]string{"Loan", "-1234.50"})
+	table.SetAccountingNegatives(1, true)
+	table.Render()
+
+	want := `+---------+------------+
+| ACCOUNT |  BALANCE   |
++---------+------------+
+| Cash    |    5000.00 |
+| Loan    | (1,234.50) |
++---------+------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("accounting rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	table := NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	if err := table.Validate(); err != nil {
+		t.Errorf("valid table reported %v", err)
+	}
+
+	table.SetAlignment(9)
+	if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "invalid alignment 9") {
+		t.Errorf("invalid alignment not reported, got %v", err)
+	}
+
+	table.SetAlignment(ALIGN_LEFT)
+	table.SortByColumn(3, false)
+	if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "sort column 3 out of range") {
+		t.Errorf("out of range sort column not reported, got %v", err)
+	}
+}
+
+func TestEmptyTableText(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.SetEmptyTableText("(no data)")
+	table.Render()
+
+	want := `+------+------+--------+
+| NAME | SIGN | RATING |
++------+------+--------+
+|      (no data)       |
++------+------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("empty table rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestEmptyTableTextNoColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetEmptyTableText("(no data)")
+	table.Render()
+
+	want := `+-----------+
+| (no data) |
++-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("empty table rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestNaturalWidth(t *testing.T) {
+	data := [][]string{
+		[]string{"Learn East has computers with adapted keyboards with enlarged print etc", "Some Data"},
+		[]string{"Instead of lining up the letters all", "the way across, he splits the keyboard in two"},
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.AppendBulk(data)
+	natural := table.NaturalWidth()
+	if wrapped := table.getTableWidth(); natural <= wrapped {
+		t.Errorf("natural width %d should exceed the wrapped width %d", natural, wrapped)
+	}
+
+	table.SetColWidth(0)
+	table.Recalculate()
+	table.Render()
+	widest := 0
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if w := DisplayWidth(line); w > widest {
+			widest = w
+		}
+	}
+	if natural != widest {
+		t.Errorf("natural width %d does not match single line rendering width %d", natural, widest)
+	}
+}
+
+func TestCellWrap(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(20)
+	table.Append([]string{"prose", "a sentence that is long enough to wrap"})
+	table.Append([]string{"code", "x := compute(a, b, c)"})
+	table.SetCellWrap(1, 1, false)
+	table.Render()
+
+	want := `+-------+-----------------------+
+| prose | a sentence that is    |
+|       | long enough to wrap   |
+| code  | x := compute(a, b, c) |
++-------+-----------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("cell wrap rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRowLabels(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"North", "South"})
+	table.Append([]string{"10", "20"})
+	table.Append([]string{"30", "40"})
+	table.SetRowLabels([]string{"Q1", "Q2"})
+	table.SetRowLabelHeader("Quarter")
+	table.Render()
+
+	want := `+---------+-------+-------+
+| QUARTER | NORTH | SOUTH |
++---------+-------+-------+
+| Q1      |    10 |    20 |
+| Q2      |    30 |    40 |
++---------+-------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("row labels rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRowFilter(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sales"})
+	table.AppendBulk([][]string{
+		[]string{"A very long product name", "50"},
+		[]string{"Gadget", "150"},
+		[]string{"Widget", "300"},
+	})
+	table.SetRowFilter(func(cells []string) bool {
+		n, _ := strconv.Atoi(cells[1])
+		return n > 100
+	})
+	table.SetRecalcOnFilter(true)
+	table.Render()
+
+	want := `+--------+-------+
+|  NAME  | SALES |
++--------+-------+
+| Gadget |   150 |
+| Widget |   300 |
++--------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("row filter rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRowFilterMaxColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"ID", "A", "B", "C", "D"})
+	table.Append([]string{"1", "a1", "b1", "c1", "d1"})
+	table.Append([]string{"2", "a long value", "b2", "c2", "x"})
+	table.SetRowFilter(func(cells []string) bool {
+		return cells[4] != "x"
+	})
+	table.SetRecalcOnFilter(true)
+	table.SetMaxColumns(3)
+	table.Render()
+
+	want := `+----+----+----+
+| ID | A  | B  |
++----+----+----+
+|  1 | a1 | b1 |
++----+----+----+
+
++----+----+----+
+| ID | C  | D  |
++----+----+----+
+|  1 | c1 | d1 |
++----+----+----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("filtered blocks rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMinTableWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Key", "Value"})
+	table.Append([]string{"cpu", "12"})
+	table.SetMinTableWidth(50)
+	table.Render()
+
+	want := `+-----+------------------------------------------+
+| KEY |                  VALUE                   |
++-----+------------------------------------------+
+| cpu |                                       12 |
++-----+------------------------------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("minimum width rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(got), "\n") {
+		if w := DisplayWidth(line); w != 50 {
+			t.Errorf("line %q is %d wide, want 50", line, w)
+		}
+	}
+}
+
+func TestMixedSeparators(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+	table.Append([]string{"B", "The Bad"})
+	table.SetColumnSeparator("│")
+	table.Render()
+
+	want := `+------+----------+
+│ NAME │   SIGN   │
++------+----------+
+│ A    │ The Good │
+│ B    │ The Bad  │
++------+----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("mixed separator rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestCellTransform(t *testing.T) {
+	var buf bytes.Buffer
+	card := regexp.MustCompile(`^\d{4}(-?\d{4}){3}$`)
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Payment"})
+	table.SetCellTransform(func(row, col int, value string) string {
+		if card.MatchString(value) {
+			return "****-" + value[len(value)-4:]
+		}
+		return value
+	})
+	table.Append([]string{"Alice", "4111-1111-1111-1111"})
+	table.Append([]string{"Bob", "invoice"})
+	table.Render()
+
+	want := `+-------+-----------+
+| NAME  |  PAYMENT  |
++-------+-----------+
+| Alice | ****-1111 |
+| Bob   | invoice   |
++-------+-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("cell transform rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestCellTransformExports(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Name", "Card"})
+	table.SetCellTransform(func(row, col int, value string) string {
+		if col == 1 {
+			return "****" + value[len(value)-4:]
+		}
+		return value
+	})
+	table.Append([]string{"Alice", "4111111111111111"})
+	table.Recalculate()
+
+	var csvOut, markdown, html bytes.Buffer
+	if err := table.WriteCSV(&csvOut); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.RenderMarkdown(&markdown); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.RenderHTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	for name, out := range map[string]string{
+		"csv":      csvOut.String(),
+		"markdown": markdown.String(),
+		"html":     html.String(),
+	} {
+		if strings.Contains(out, "4111111111111111") || !strings.Contains(out, "****1111") {
+			t.Errorf("%s output is not masked\ngot:\n%s\n", name, out)
+		}
+	}
+}
+
+func TestCellTransformMultiline(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Name", "Card"})
+	table.SetCellTransform(func(row, col int, value string) string {
+		if col == 1 {
+			return "****" + value[len(value)-4:]
+		}
+		return value
+	})
+	table.AppendMultiline([][]string{{"Alice", "Smith"}, {"4111111111111111"}})
+
+	var csvOut, markdown, html bytes.Buffer
+	if err := table.WriteCSV(&csvOut); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.RenderMarkdown(&markdown); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.RenderHTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	for name, out := range map[string]string{
+		"text":     table.RenderString(),
+		"csv":      csvOut.String(),
+		"markdown": markdown.String(),
+		"html":     html.String(),
+	} {
+		if strings.Contains(out, "4111111111111111") || !strings.Contains(out, "****1111") {
+			t.Errorf("%s output is not masked\ngot:\n%s\n", name, out)
+		}
+	}
+}
+
+func TestRenderGrid(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "City"})
+	table.Append([]string{"Kenji", "東京"})
+	table.Append([]string{"Ann", "Lagos"})
+	table.SetCaption(true, "Offices")
+	table.Render()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	grid := table.RenderGrid()
+	if len(grid) != len(lines) {
+		t.Fatalf("grid has %d rows, want %d", len(grid), len(lines))
+	}
+	width := DisplayWidth(lines[0])
+	for i, row := range grid {
+		if len(row) != width {
+			t.Errorf("grid row %d has %d cells, want %d", i, len(row), width)
+		}
+	}
+	if got, want := strings.TrimRight(string(grid[1]), " "), lines[1]; got != want {
+		t.Errorf("grid row Wants: %q Got: %q", want, got)
+	}
+}
+
+func TestHeaderSeparatorHidden(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"B", "The Bad", "288"})
+	table.SetHeaderSeparatorVisible(false)
+	table.Render()
+
+	want := `+------+----------+--------+
+| NAME     SIGN     RATING |
++------+----------+--------+
+| A    | The Good |    500 |
+| B    | The Bad  |    288 |
++------+----------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("header separator rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRowHashColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Qty"})
+	table.Append([]string{"Apple", "3"})
+	table.Append([]string{"Pear", "10"})
+	table.SetRowHashColumn("CRC", func(cells []string) string {
+		sum := crc32.ChecksumIEEE([]byte(strings.Join(cells, "\x00")))
+		return fmt.Sprintf("%08x", sum)
+	})
+	table.Render()
+
+	want := fmt.Sprintf(`+-------+-----+----------+
+| NAME  | QTY |   CRC    |
++-------+-----+----------+
+| Apple |   3 | %08x |
+| Pear  |  10 | %08x |
++-------+-----+----------+
+`, crc32.ChecksumIEEE([]byte("Apple\x003")), crc32.ChecksumIEEE([]byte("Pear\x0010")))
+	got := buf.String()
+	if got != want {
+		t.Errorf("row hash rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestAppendMaps(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.AppendMaps([]map[string]string{
+		{"name": "Ann", "city": "Lagos"},
+		{"name": "Bob", "age": "41"},
+		{"city": "Oslo", "age": "29", "name": "Cid"},
+	})
+	table.Render()
+
+	want := `+-----+-------+------+
+| AGE | CITY  | NAME |
++-----+-------+------+
+|     | Lagos | Ann  |
+|  41 |       | Bob  |
+|  29 | Oslo  | Cid  |
++-----+-------+------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("map rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestTruncationFormat(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name"})
+	for i := 1; i <= 10; i++ {
+		table.Append([]string{strconv.Itoa(i), "item" + strconv.Itoa(i)})
+	}
+	table.SetMaxRows(3)
+	table.Render()
+	if !strings.Contains(buf.String(), "| … (7 more … |") {
+		t.Errorf("default truncation message missing:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	table.SetTruncationFormat(func(hidden int) string {
+		return fmt.Sprintf("and %d more…", hidden)
+	})
+	table.Render()
+
+	want := `+----+--------+
+| ID |  NAME  |
++----+--------+
+|  1 | item1  |
+|  2 | item2  |
+|  3 | item3  |
+| and 7 more… |
++----+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("truncation format rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestDecimalColumnWithLabels(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Metric", "Value"})
+	table.Append([]string{"latency", "12.5"})
+	table.Append([]string{"throughput", "1024"})
+	table.Append([]string{"error rate", "0.125"})
+	table.SetColumnType(0, ColumnString)
+	table.SetColumnType(1, ColumnDecimal)
+	table.Render()
+
+	want := `+------------+----------+
+|   METRIC   |  VALUE   |
++------------+----------+
+| latency    |   12.5   |
+| throughput | 1024     |
+| error rate |    0.125 |
++------------+----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("decimal column rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestFreezeLayout(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Host", "Load"})
+	table.Append([]string{"web-1", "0.42"})
+	table.FreezeLayout()
+
+	var first, second bytes.Buffer
+	if err := table.RenderBody(&first); err != nil {
+		t.Fatal(err)
+	}
+	table.Append([]string{"database-primary", "12.75"})
+	if err := table.RenderBody(&second); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := first.String(), "| web-1 | 0.42 |\n"; got != want {
+		t.Errorf("first body Wants: %q Got: %q", want, got)
+	}
+	if got, want := second.String(), "| web-1 | 0.42 |\n| data… | 12.… |\n"; got != want {
+		t.Errorf("second body Wants: %q Got: %q", want, got)
+	}
+
+	table.Render()
+	want := `+-------+------+
+| HOST  | LOAD |
++-------+------+
+| web-1 | 0.42 |
+| data… | 12.… |
++-------+------+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("frozen rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestFreezeLayoutNewData(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Id", "Name"})
+	table.Append([]string{"1", "a name that sets the width"})
+	table.FreezeLayout()
+	first := table.RenderString()
+
+	table.ClearRows()
+	table.Append([]string{"2", "another"})
+	table.AppendMultiline([][]string{{"3"}, {"a multiline cell far wider than the frozen column", "x"}})
+	table.Recalculate()
+	second := table.RenderString()
+
+	width := DisplayWidth(getLines(first)[0])
+	for _, line := range getLines(strings.TrimSuffix(first+second, nl)) {
+		if w := DisplayWidth(line); w != width {
+			t.Errorf("line %q is %d wide, want %d", line, w, width)
+		}
+	}
+}
+
+func TestNewlineOnlyCell(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Key", "Note"})
+	table.Append([]string{"a", "\n\n"})
+	table.Append([]string{"b", " \n \n "})
+	table.Append([]string{"c", "ok"})
+	table.Render()
+
+	want := `+-----+------+
+| KEY | NOTE |
++-----+------+
+| a   |      |
+| b   |      |
+| c   | ok   |
++-----+------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("blank cell rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetPreserveBlankLines(true)
+	table.SetHeader([]string{"Key", "Note"})
+	table.Append([]string{"a", "\n\n"})
+	table.Render()
+
+	want = `+-----+------+
+| KEY | NOTE |
++-----+------+
+| a   |      |
+|     |      |
+|     |      |
++-----+------+
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("preserved blank cell rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestTrimWhitespaceOnlyCells(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Key", "Note"})
+	table.Append([]string{"a", "          "})
+	table.Append([]string{"b", "ok"})
+	table.Render()
+
+	want := `+-----+------+
+| KEY | NOTE |
++-----+------+
+| a   |      |
+| b   | ok   |
++-----+------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("trimmed cell rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetTrimWhitespaceOnlyCells(false)
+	table.SetHeader([]string{"Key", "Note"})
+	table.Append([]string{"a", "      "})
+	table.Append([]string{"b", "ok"})
+	table.Render()
+
+	want = `+-----+--------+
+| KEY |  NOTE  |
++-----+--------+
+| a   |        |
+| b   | ok     |
++-----+--------+
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("untrimmed cell rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRTLAutoIndex(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "City"})
+	table.Append([]string{"Ann", "Lagos"})
+	table.Append([]string{"Bob", "Oslo"})
+	table.SetRowLabelHeader("#")
+	table.SetAutoIndex(true)
+	table.SetRTL(true)
+	table.Render()
+
+	want := `+-------+------+---+
+| CITY  | NAME | # |
++-------+------+---+
+| Lagos | Ann  | 1 |
+| Oslo  | Bob  | 2 |
++-------+------+---+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("rtl auto index rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMaxColumnsAutoIndex(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"A", "B", "C"})
+	table.Append([]string{"a1", "b1", "c1"})
+	table.SetAutoIndex(true)
+	table.SetMaxColumns(3)
+	table.Render()
+
+	want := `+---+----+----+
+|   | A  | B  |
++---+----+----+
+| 1 | a1 | b1 |
++---+----+----+
+
++---+----+
+|   | C  |
++---+----+
+| 1 | c1 |
++---+----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("auto index blocks rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMaxColumnsRTL(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"ID", "A", "B", "C", "D"})
+	table.Append([]string{"1", "a1", "b1", "c1", "d1"})
+	table.SetRTL(true)
+	table.SetMaxColumns(3)
+	table.Render()
+
+	want := `+----+----+----+
+| B  | A  | ID |
++----+----+----+
+| b1 | a1 |  1 |
++----+----+----+
+
++----+----+----+
+| D  | C  | ID |
++----+----+----+
+| d1 | c1 |  1 |
++----+----+----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("rtl blocks rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestAutoIndexRowLabels(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Sales"})
+	table.Append([]string{"950"})
+	table.Append([]string{"800"})
+	table.SetRowLabels([]string{"North", "South"})
+	table.SetRowLabelHeader("Region")
+	table.SetAutoIndex(true)
+	table.SortByColumn(0, false)
+	table.Render()
+
+	want := `+---+--------+-------+
+|   | REGION | SALES |
++---+--------+-------+
+| 1 | South  |   800 |
+| 2 | North  |   950 |
++---+--------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("indexed labels rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestColumnWidthMap(t *testing.T) {
+	var a, b bytes.Buffer
+	first := NewWriter(&a)
+	first.SetHeader([]string{"Region", "Sales"})
+	first.Append([]string{"North", "1200"})
+	second := NewWriter(&b)
+	second.SetHeader([]string{"Id", "Total"})
+	second.Append([]string{"Southwest", "98"})
+
+	widths := first.ColumnWidthMap()
+	widths[0] = 99
+	if first.cs[0] == 99 {
+		t.Fatal("ColumnWidthMap did not return a copy")
+	}
+
+	first.ApplyColumnWidthMap(second.ColumnWidthMap())
+	second.ApplyColumnWidthMap(first.ColumnWidthMap())
+	first.Render()
+	second.Render()
+
+	want := `+-----------+-------+
+|  REGION   | SALES |
++-----------+-------+
+| North     |  1200 |
++-----------+-------+
++-----------+-------+
+|    ID     | TOTAL |
++-----------+-------+
+| Southwest |    98 |
++-----------+-------+
+`
+	if got := a.String() + b.String(); got != want {
+		t.Errorf("aligned rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMaxRowHeight(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Summary"})
+	table.SetColWidth(20)
+	table.SetMaxRowHeight(3)
+	table.Append([]string{"1", "The quick brown fox jumps over the lazy dog while the cat watches from the warm windowsill all afternoon"})
+	table.Render()
+
+	want := `+----+----------------------+
+| ID |       SUMMARY        |
++----+----------------------+
+|  1 | The quick brown fox  |
+|    | jumps over the lazy  |
+|    | dog while the cat…   |
++----+----------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("row height rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestHeaderGap(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+	table.SetHeaderGap(1)
+	table.Render()
+
+	want := `+------+----------+
+| NAME |   SIGN   |
++------+----------+
+|      |          |
+| A    | The Good |
++------+----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("header gap rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestInteriorSeparatorsOff(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"B", "The Bad", "288"})
+	table.SetFooter([]string{"", "Total", "788"})
+	table.SetFooterAutoNumericAlign(true)
+	table.SetInteriorSeparators(false)
+	table.Render()
+
+	want := `+--------------------------+
+| NAME     SIGN     RATING |
++--------------------------+
+| A      The Good      500 |
+| B      The Bad       288 |
++--------------------------+
+|         TOTAL        788 |
++--------------------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("interior separator rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestValidateColumnSettings(t *testing.T) {
+	table := NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT, ALIGN_LEFT})
+	if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "column alignment set for 3 columns") {
+		t.Errorf("long column alignment not reported, got %v", err)
+	}
+
+	table.SetColumnAlignment(nil)
+	for _, col := range []int{9, 4, 7, 2, 5} {
+		table.SetColumnType(col, ColumnNumber)
+	}
+	for i := 0; i < 20; i++ {
+		if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "column 2 out of range") {
+			t.Fatalf("first out of range column not reported, got %v", err)
+		}
+	}
+}
+
+func TestValidateSeparatorEscapes(t *testing.T) {
+	table := NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.SetColumnSeparator("\033[31m|\033[0m")
+	if err := table.Validate(); err != nil {
+		t.Errorf("colored separator reported %v", err)
+	}
+
+	table.SetColumnSeparator("\033[31|")
+	if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "malformed escape sequence in column separator") {
+		t.Errorf("unterminated escape not reported, got %v", err)
+	}
+}
+
+func TestAppendStructJSONTags(t *testing.T) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"full_name,omitempty"`
+		Email    string `json:",omitempty"`
+		Password string `json:"-"`
+		internal bool
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	if err := table.AppendStruct(user{1, "Ann Lee", "ann@example.com", "secret", true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.AppendStruct(&user{ID: 2, Name: "Bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.AppendStruct("text"); err == nil {
+		t.Error("appending a string as a struct did not fail")
+	}
+	table.Render()
+
+	want := `+----+-----------+-----------------+
+| ID | FULL NAME |      EMAIL      |
++----+-----------+-----------------+
+|  1 | Ann Lee   | ann@example.com |
+|  2 | Bob       |                 |
++----+-----------+-----------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("struct rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestDistributeWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Title", "Description"})
+	table.Append([]string{"1", "An introduction to tables", "A long description of how text tables are laid out in a terminal"})
+	table.Append([]string{"2", "Wrapping", "Cells are wrapped to fit"})
+	table.DistributeWidth(50)
+	table.Render()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for _, line := range lines {
+		if w := DisplayWidth(line); w > 50 {
+			t.Errorf("line %q is %d wide, want at most 50", line, w)
+		}
+	}
+	if w := DisplayWidth(lines[0]); w < 45 {
+		t.Errorf("table is only %d wide, want close to 50", w)
+	}
+	if sum := table.cs[0] + table.cs[1] + table.cs[2]; sum > 50-10 {
+		t.Errorf("column widths sum to %d, want at most 40", sum)
+	}
+}
+
+func TestDistributeWidthKeepsMaxWidth(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Id", "Title", "Description"})
+	table.Append([]string{"1", "An introduction to tables", "A long description of how text tables are laid out in a terminal"})
+	table.SetColMaxWidth(2, 30)
+	table.DistributeWidth(200)
+	if got := table.colMax[2]; got != 30 {
+		t.Errorf("fitting table changed the cap to %d, want 30", got)
+	}
+
+	table.SetColMaxWidth(1, 8)
+	table.DistributeWidth(60)
+	if got := table.colMax[1]; got != 8 {
+		t.Errorf("distributed cap %d replaced the tighter cap 8", got)
+	}
+	if got := table.colMax[2]; got > 30 {
+		t.Errorf("distributed cap %d exceeds the user cap 30", got)
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+
+	want := `+------+----------+
+| NAME |   SIGN   |
++------+----------+
+| A    | The Good |
++------+----------+
+`
+	if got := table.RenderString(); got != want {
+		t.Errorf("string rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("RenderString wrote %d bytes to the writer", buf.Len())
+	}
+}
+
+type failingWriter struct {
+	writes int
+	limit  int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if f.writes >= f.limit {
+		return 0, io.ErrClosedPipe
+	}
+	f.writes++
+	return len(p), nil
+}
+
+func TestRenderWriteError(t *testing.T) {
+	w := &failingWriter{limit: 3}
+	table := NewWriter(w)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A", "The Good"})
+	table.SetCaption(true)
+
+	if err := table.Render(); err != io.ErrClosedPipe {
+		t.Errorf("Render Wants: %v Got: %v", io.ErrClosedPipe, err)
+	}
+	if w.writes != 3 {
+		t.Errorf("Render kept writing after the failure, %d writes", w.writes)
+	}
+
+	var buf bytes.Buffer
+	table.out = &buf
+	if err := table.Render(); err != nil {
+		t.Errorf("Render to a buffer failed: %v", err)
+	}
+}
+
+func TestRenderCSVStreamWriteError(t *testing.T) {
+	input := "n\n" + strings.Repeat("row\n", 50)
+	w := &failingWriter{limit: 3}
+	err := RenderCSVStream(w, strings.NewReader(input), CSVOptions{HasHeader: true, SampleRows: 2})
+	if err != io.ErrClosedPipe {
+		t.Errorf("RenderCSVStream Wants: %v Got: %v", io.ErrClosedPipe, err)
+	}
+	if w.writes != 3 {
+		t.Errorf("RenderCSVStream kept writing after the failure, %d writes", w.writes)
+	}
+}
+
+func TestWriteCSVBOM(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "City"})
+	table.Append([]string{"Zoë", "Köln"})
+	table.SetCSVUTF8BOM(true)
+	if err := table.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "\xef\xbb\xbfName,City\nZoë,Köln\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV Wants: %q Got: %q", want, got)
+	}
+}
+
+func TestDiffRender(t *testing.T) {
+	build := func(rows [][]string) *Table {
+		table := NewWriter(&bytes.Buffer{})
+		table.SetHeader([]string{"Host", "Status", "Load"})
+		table.AppendBulk(rows)
+		return table
+	}
+	prev := build([][]string{
+		{"web-1", "up", "0.40"},
+		{"web-2", "up", "0.55"},
+		{"db-1", "up", "1.20"},
+	})
+	cur := build([][]string{
+		{"web-1", "up", "0.40"},
+		{"web-2", "down", "0.55"},
+		{"db-1", "up", "1.75"},
+	})
+
+	want := []CellChange{
+		{Row: 1, Col: 1, Text: "down  ", X: 10, Y: 4},
+		{Row: 2, Col: 2, Text: "1.75", X: 19, Y: 5},
+	}
+	got := cur.DiffRender(prev)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("DiffRender Wants: %+v Got: %+v", want, got)
+	}
+
+	lines := strings.Split(cur.RenderString(), "\n")
+	for _, c := range got {
+		if line := []rune(lines[c.Y]); string(line[c.X:c.X+len(c.Text)]) != c.Text {
+			t.Errorf("change %+v does not match rendered line %q", c, lines[c.Y])
+		}
+	}
+}
+
+func TestDiffRenderAutoIndex(t *testing.T) {
+	build := func(status string) *Table {
+		table := NewWriter(&bytes.Buffer{})
+		table.SetHeader([]string{"Host", "Status"})
+		table.Append([]string{"web-1", "up"})
+		table.Append([]string{"web-2", status})
+		table.SetAutoIndex(true)
+		return table
+	}
+	cur := build("down")
+
+	want := []CellChange{{Row: 1, Col: 1, Text: "down  ", X: 14, Y: 4}}
+	got := cur.DiffRender(build("up"))
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("DiffRender Wants: %+v Got: %+v", want, got)
+	}
+}
+
+func TestDiffRenderNoBorder(t *testing.T) {
+	build := func(load string) *Table {
+		table := NewWriter(&bytes.Buffer{})
+		table.SetHeader([]string{"Host", "Load"})
+		table.SetHeaderSpan(0, 2)
+		table.Append([]string{"web-1", "0.40"})
+		table.Append([]string{"db-1", load})
+		table.SetStyle(StyleUnicode)
+		table.SetBorder(false)
+		table.SetRowLine(true)
+		return table
+	}
+	cur := build("1.75")
+
+	want := []CellChange{{Row: 1, Col: 1, Text: "1.75", X: 10, Y: 4}}
+	got := cur.DiffRender(build("1.20"))
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("DiffRender Wants: %+v Got: %+v", want, got)
+	}
+
+	lines := strings.Split(cur.RenderString(), "\n")
+	for _, c := range got {
+		if line := []rune(lines[c.Y]); string(line[c.X:c.X+len(c.Text)]) != c.Text {
+			t.Errorf("change %+v does not match rendered line %q", c, lines[c.Y])
+		}
+	}
+}
+
+func TestColumnAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Code", "Name", "Note", "Qty"})
+	table.Append([]string{"007", "Widget", "ok", "12"})
+	table.Append([]string{"42", "Gadget with a longer name", "backorder", "3"})
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_DEFAULT, ALIGN_RIGHT})
+	table.Render()
+
+	want := `+------+---------------------------+-----------+-----+
+| CODE |           NAME            |   NOTE    | QTY |
++------+---------------------------+-----------+-----+
+| 007  | Widget                    |        ok |  12 |
+| 42   | Gadget with a longer name | backorder |   3 |
++------+---------------------------+-----------+-----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("column alignment rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	table.SetColumnAlignment([]int{9})
+	if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "invalid alignment 9 for column 0") {
+		t.Errorf("invalid column alignment not reported, got %v", err)
+	}
+}
+
+func TestFooterKeepsDecimalPoint(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Rate", "Amount"})
+	table.SetFooter([]string{"Total", "3.25", "$1,024.50"})
+	table.Append([]string{"Hosting", "1.25", "$1,000.00"})
+	table.Append([]string{"Domain", "2.00", "$24.50"})
+	table.Render()
+
+	want := `+---------+------+-----------+
+|  ITEM   | RATE |  AMOUNT   |
++---------+------+-----------+
+| Hosting | 1.25 | $1,000.00 |
+| Domain  | 2.00 |    $24.50 |
++---------+------+-----------+
+|  TOTAL  | 3.25 | $1,024.50 |
++---------+------+-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("decimal footer rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestFooterAggregateMaxColumns(t *testing.T) {
+	count := func(cells []string) string {
+		return strconv.Itoa(len(cells))
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"ID", "A", "B", "C"})
+	table.SetFooter([]string{"Rows", "", "-", "-"})
+	table.Append([]string{"1", "a1", "b1", "c1"})
+	table.Append([]string{"2", "a2", "b2", "c2"})
+	table.SetFooterAggregate(1, count)
+	table.SetMaxColumns(3)
+	table.Render()
+
+	want := `+------+----+----+
+|  ID  | A  | B  |
++------+----+----+
+|    1 | a1 | b1 |
+|    2 | a2 | b2 |
++------+----+----+
+| ROWS |  2 | -  |
++------+----+----+
+
++------+----+
+|  ID  | C  |
++------+----+
+|    1 | c1 |
+|    2 | c2 |
++------+----+
+| ROWS | -  |
++------+----+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("aggregate blocks rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestFooterAggregateAlignment(t *testing.T) {
+	sum := func(cells []string) string {
+		total := 0.0
+		for _, v := range cells {
+			if n, err := parseNumber(v); err == nil {
+				total += n
+			}
+		}
+		return fmt.Sprintf("$%.2f", total)
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Amount"})
+	table.SetFooter([]string{"Total", ""})
+	table.Append([]string{"Domain name", "$10.98"})
+	table.Append([]string{"Hosting", "$54.95"})
+	table.Append([]string{"Bandwidth", "$1030.00"})
+	table.SetFooterAggregate(1, sum)
+	table.Render()
+
+	want := `+-------------+----------+
+|    ITEM     |  AMOUNT  |
++-------------+----------+
+| Domain name |   $10.98 |
+| Hosting     |   $54.95 |
+| Bandwidth   | $1030.00 |
++-------------+----------+
+|    TOTAL    | $1095.93 |
++-------------+----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("aggregate footer rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table.SetFooterAggregateAlignment(ALIGN_CENTER)
+	table.Append([]string{"Support", "$4.07"})
+	table.Render()
+	if !strings.Contains(buf.String(), "| $1100.00 |") {
+		t.Errorf("aggregate footer not recomputed:\n%s", buf.String())
+	}
+}
+
+func TestPercentRightAligned(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Region", "Share"})
+	table.Append([]string{"North", "12.5%"})
+	table.Append([]string{"South", "7%"})
+	table.Append([]string{"East", "100%"})
+	table.Render()
+
+	want := `+--------+-------+
+| REGION | SHARE |
++--------+-------+
+| North  | 12.5% |
+| South  |    7% |
+| East   |  100% |
++--------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("percent rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestLongWrappingHeader(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(10)
+	table.SetHeader([]string{"Id", "Total number of requests served"})
+	table.Append([]string{"1", "12"})
+	table.Append([]string{"2", "3400"})
+	table.Render()
+
+	want := `+----+------------+
+| ID |   TOTAL    |
+|    | NUMBER OF  |
+|    |  REQUESTS  |
+|    |   SERVED   |
++----+------------+
+|  1 |         12 |
+|  2 |       3400 |
++----+------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("wrapped header rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestColumnZeroPad(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name"})
+	table.Append([]string{"7", "Ann"})
+	table.Append([]string{"42", "Bob"})
+	table.Append([]string{"n/a", "Cid"})
+	table.SetColumnZeroPad(0, 4)
+	table.Render()
+
+	want := `+------+------+
+|  ID  | NAME |
++------+------+
+| 0007 | Ann  |
+| 0042 | Bob  |
+| n/a  | Cid  |
++------+------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("zero pad rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestColMaxWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(0)
+	table.SetHeader([]string{"Package", "Description", "License"})
+	table.Append([]string{"github.com/olekukonko/tablewriter", "ASCII table in golang with wrapping support", "MIT License"})
+	table.SetColMaxWidth(1, 16)
+	table.Render()
+
+	want := `+-----------------------------------+------------------+-------------+
+|              PACKAGE              |   DESCRIPTION    |   LICENSE   |
++-----------------------------------+------------------+-------------+
+| github.com/olekukonko/tablewriter | ASCII table      | MIT License |
+|                                   | in golang with   |             |
+|                                   | wrapping support |             |
++-----------------------------------+------------------+-------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("column max width rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Name", "Notes", "Amount"})
+	table.Append([]string{"Ann", "first line\nsecond | line", "1200"})
+	table.Append([]string{"Bob", "ok", "35.50"})
+
+	var buf bytes.Buffer
+	if err := table.RenderMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `| NAME | NOTES                        | AMOUNT |
+| ---- | ---------------------------- | -----: |
+| Ann  | first line<br>second \| line |   1200 |
+| Bob  | ok                           |  35.50 |
+`
+	if got := buf.String(); got != want {
+		t.Errorf("markdown rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderMarkdownFooter(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Item", "Note", "Amount"})
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_CENTER, ALIGN_DEFAULT})
+	table.SetColumnType(2, ColumnNumber)
+	table.Append([]string{"Tea", "green", "4.50"})
+	table.Append([]string{"Cake", "", "12.00"})
+	table.SetFooter([]string{"total", "", "16.50"})
+
+	var buf bytes.Buffer
+	if err := table.RenderMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `| ITEM  | NOTE  | AMOUNT |
+| :---- | :---: | -----: |
+| Tea   | green |   4.50 |
+| Cake  |       |  12.00 |
+| TOTAL |       |  16.50 |
+`
+	if got := buf.String(); got != want {
+		t.Errorf("markdown rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Name", "Comment", "Score"})
+	table.Append([]string{"Ann", "<script>alert(1)</script>", "10"})
+	table.Append([]string{"Bob & Co", "first\nsecond", "7"})
+	table.SetFooter([]string{"", "total", "17"})
+
+	var buf bytes.Buffer
+	if err := table.RenderHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `<table>
+<thead>
+<tr><th>NAME</th><th>COMMENT</th><th>SCORE</th></tr>
+</thead>
+<tbody>
+<tr><td>Ann</td><td>&lt;script&gt;alert(1)&lt;/script&gt;</td><td style="text-align:right">10</td></tr>
+<tr><td>Bob &amp; Co</td><td>first<br>second</td><td style="text-align:right">7</td></tr>
+</tbody>
+<tfoot>
+<tr><td></td><td>TOTAL</td><td style="text-align:right">17</td></tr>
+</tfoot>
+</table>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("html rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderMarkdownRTL(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"a", "b", "c"})
+	table.Append([]string{"one", "two", "3"})
+	table.SetColumnZeroPad(2, 3)
+	table.SetRTL(true)
+
+	var buf bytes.Buffer
+	if err := table.RenderMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `|   C | B   | A   |
+| --: | --- | --- |
+| 003 | two | one |
+`
+	if got := buf.String(); got != want {
+		t.Errorf("markdown rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderHTMLFormatting(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"Id", "Name", "Balance"})
+	table.Append([]string{"7", "Ann", "-1200"})
+	table.SetColumnZeroPad(0, 3)
+	table.SetAccountingNegatives(2, true)
+	table.SetRTL(true)
+
+	var buf bytes.Buffer
+	if err := table.RenderHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `<table>
+<thead>
+<tr><th>BALANCE</th><th>NAME</th><th>ID</th></tr>
+</thead>
+<tbody>
+<tr><td style="text-align:right">(1,200)</td><td>Ann</td><td style="text-align:right">007</td></tr>
+</tbody>
+</table>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("html rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestColMinWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"C", "Name"})
+	table.Append([]string{"a", "Alpha"})
+	table.Append([]string{"b", "Beta"})
+	table.SetColMinWidth(0, 6)
+	table.Render()
+
+	want := `+--------+-------+
+|   C    | NAME  |
++--------+-------+
+| a      | Alpha |
+| b      | Beta  |
++--------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("column min width rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	table.SetColMaxWidth(0, 4)
+	if err := table.Validate(); err == nil || !strings.Contains(err.Error(), "minimum width 6 exceeds maximum width 4") {
+		t.Errorf("conflicting widths not reported, got %v", err)
+	}
+}
+
+func TestClearRows(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign"})
+	table.SetBorder(false)
+	table.Append([]string{"A very long name", "The Good"})
+	table.Render()
+
+	buf.Reset()
+	table.ClearRows()
+	table.Append([]string{"B", "Bad"})
+	table.Render()
+
+	want := `  NAME | SIGN  
++------+------+
+  B    | Bad   
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("cleared table rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestClearRowsFrozen(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Id", "Name"})
+	table.Append([]string{"1", "short"})
+	table.FreezeLayout()
+	table.ClearRows()
+	table.Append([]string{"2", "Bartholomew"})
+	table.Render()
+
+	want := `+----+-------+
+| ID | NAME  |
++----+-------+
+|  2 | Bart… |
++----+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("frozen cleared table rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestHeaderSpanFill(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Contact", ""})
+	table.Append([]string{"Ann", "ann@example.com", "555-0100"})
+	table.SetHeaderSpan(1, 2)
+	table.SetSpanFillChar(" ")
+	table.Render()
+
+	want := `+------+----------------- ----------+
+| NAME |          CONTACT           |
++------+-----------------+----------+
+| Ann  | ann@example.com | 555-0100 |
++------+-----------------+----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("header span rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestNumLinesColumnCount(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	if table.NumLines() != 0 || table.ColumnCount() != 0 {
+		t.Errorf("empty table has %d lines and %d columns", table.NumLines(), table.ColumnCount())
+	}
+	table.SetHeader([]string{"Name", "Sign"})
+	table.Append([]string{"A"})
+	table.Append([]string{"B", "The Bad"})
+	table.Append([]string{"C", "The Ugly", "extra"})
+
+	if got := table.NumLines(); got != 3 {
+		t.Errorf("NumLines Wants: 3 Got: %d", got)
+	}
+	if got := table.ColumnCount(); got != 3 {
+		t.Errorf("ColumnCount Wants: 3 Got: %d", got)
+	}
+}
+
+func TestFooterAffectsWidth(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Item", "Cost"})
+	table.SetFooter([]string{"Total", "Not yet calculated"})
+	table.Append([]string{"Disk", "$80"})
+	table.SetFooterAffectsWidth(false)
+	table.Render()
+
+	want := `+------+------+
+| ITEM | COST |
++------+------+
+| Disk |  $80 |
++------+------+
+| TOT… | NOT… |
++------+------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("footer width rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestAppendStructs(t *testing.T) {
+	type server struct {
+		Host   string
+		Port   int    `tablewriter:"Listen Port"`
+		Secret string `tablewriter:"-"`
+		Up     bool
+	}
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	err := table.AppendStructs([]*server{
+		{"web-1", 80, "x", true},
+		nil,
+		{"db-1", 5432, "y", false},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+-------+-------------+-------+
+| HOST  | LISTEN PORT |  UP   |
++-------+-------------+-------+
+| web-1 |          80 | true  |
+| db-1  |        5432 | false |
++-------+-------------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("structs rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	if err := table.AppendStructs(server{}); err == nil {
+		t.Error("appending a struct as a slice did not fail")
+	}
+	if err := table.AppendStructs([]int{1}); err == nil {
+		t.Error("appending a slice of ints did not fail")
+	}
+}
+
+func TestLegend(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Host", "State"})
+	table.Append([]string{"web-1", "✓"})
+	table.Append([]string{"db-1", "✗*"})
+	table.SetCaption(true, "Nightly checks.")
+	table.SetLegend([]string{"✓ passed, ✗ failed", "* check ran after the maintenance window closed"})
+	table.Render()
+
+	want := `+-------+-------+
+| HOST  | STATE |
++-------+-------+
+| web-1 | ✓     |
+| db-1  | ✗*    |
++-------+-------+
+Nightly checks.
+✓ passed, ✗
+failed
+* check ran after
+the maintenance
+window closed
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("legend rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestNewCSVFromReader(t *testing.T) {
+	var buf bytes.Buffer
+	data := "first_name,last_name\nJohn,Barry\nKathy,Smith\n"
+	table, err := NewCSVFromReader(&buf, strings.NewReader(data), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+------------+-----------+
+| FIRST NAME | LAST NAME |
++------------+-----------+
+| John       | Barry     |
+| Kathy      | Smith     |
++------------+-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("csv reader rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	if _, err := NewCSVFromReader(&buf, strings.NewReader("a,\"b\n"), false); err == nil {
+		t.Error("malformed csv did not fail")
+	}
+}
+
+func TestHeaderFollowsColumnAlign(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Description", "Qty", "Note"})
+	table.Append([]string{"Widget", "1200", "boxed"})
+	table.Append([]string{"Gadget", "35", "loose"})
+	table.SetColumnAlignment([]int{ALIGN_DEFAULT, ALIGN_DEFAULT, ALIGN_CENTER})
+	table.SetHeaderFollowsColumnAlign(true)
+	table.Render()
+
+	want := `+-------------+------+-------+
+| DESCRIPTION |  QTY | NOTE  |
++-------------+------+-------+
+| Widget      | 1200 | boxed |
+| Gadget      |   35 | loose |
++-------------+------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("header alignment rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Note", "Description", "Qty"})
+	table.Append([]string{"boxed", "Widget", "1200"})
+	table.Append([]string{"loose", "Gadget", "35"})
+	table.SetHeaderFollowsColumnAlign(true)
+	table.HideColumn(0)
+	table.SetRTL(true)
+	table.Render()
+
+	want = `+------+-------------+
+|  QTY | DESCRIPTION |
++------+-------------+
+| 1200 | Widget      |
+|   35 | Gadget      |
++------+-------------+
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("header alignment rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestNewCSVWithDelimiter(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := NewCSVWithDelimiter(&buf, "test_semicolon.csv", true, ';')
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+---------+-------+-------+
+| PRODUKT | PREIS | MENGE |
++---------+-------+-------+
+| Kaffee  | 4,50  |     2 |
+| Tee     | 3,20  |    10 |
++---------+-------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("delimited csv rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	if _, err := NewCSVWithDelimiter(&buf, "missing.csv", true, ';'); err == nil {
+		t.Error("missing file did not fail")
+	}
+}
+
+func TestClone(t *testing.T) {
+	var buf bytes.Buffer
+	base := NewWriter(&buf)
+	base.SetHeader([]string{"Name", "Qty"})
+	base.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT})
+	base.SetColMinWidth(0, 8)
+	base.Append([]string{"Widget", "3"})
+
+	clone := base.Clone()
+	if clone.NumLines() != 0 {
+		t.Errorf("clone has %d rows, want 0", clone.NumLines())
+	}
+	clone.SetHeader([]string{"Extra"})
+	clone.SetColMinWidth(0, 20)
+	clone.colAlign[0] = ALIGN_CENTER
+	clone.Append([]string{"Gadget", "5"})
+
+	base.Render()
+	want := `+----------+-----+
+|   NAME   | QTY |
++----------+-----+
+| Widget   |   3 |
++----------+-----+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("original changed by clone\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestTruncateColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetColWidth(20)
+	table.SetHeader([]string{"Name", "Description"})
+	table.Append([]string{"Unicode", "日本語のテキストはとても長いので切り詰められます"})
+	table.Append([]string{"Colored", "\033[31mA very long description that does not fit\033[0m"})
+	table.Append([]string{"A name that is far too long", "short"})
+	table.SetTruncate(1, true)
+	table.Render()
+
+	want := "+----------------------+----------------------+\n" +
+		"|         NAME         |     DESCRIPTION      |\n" +
+		"+----------------------+----------------------+\n" +
+		"| Unicode              | 日本語のテキストは…  |\n" +
+		"| Colored              | \033[31mA very long descrip…\033[0m |\n" +
+		"| A name that is far   | short                |\n" +
+		"| too long             |                      |\n" +
+		"+----------------------+----------------------+\n"
+	got := buf.String()
+	if got != want {
+		t.Errorf("column truncation rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestUnicodeStyle(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"B", "The Bad", "288"})
+	table.SetFooter([]string{"", "Total", "788"})
+	table.SetFooterAutoNumericAlign(true)
+	table.SetStyle(StyleUnicode)
+	table.Render()
+
+	want := `┌──────┬──────────┬────────┐
+│ NAME │   SIGN   │ RATING │
+├──────┼──────────┼────────┤
+│ A    │ The Good │    500 │
+│ B    │ The Bad  │    288 │
+├──────┼──────────┼────────┤
+│         TOTAL   │    788 │
+└──────┴──────────┴────────┘
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("unicode style rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	table.Append([]string{"B", "288"})
+	table.SetRowLine(true)
+	table.SetStyle(StyleUnicode)
+	table.Render()
+
+	want = `┌──────┬────────┐
+│ NAME │ RATING │
+├──────┼────────┤
+│ A    │    500 │
+├──────┼────────┤
+│ B    │    288 │
+└──────┴────────┘
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("unicode style rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestUnicodeStyleNoBorder(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	table.Append([]string{"B", "288"})
+	table.SetFooter([]string{"Total", "788"})
+	table.SetStyle(StyleUnicode)
+	table.SetBorder(false)
+	table.Render()
+
+	want := `  NAME  │ RATING  
+────────┼─────────
+  A     │    500  
+  B     │    288  
+────────┼─────────
+  TOTAL │  788    
+────────┴─────────
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("unicode style rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestAutoMergeCells(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Category", "Item", "Price"})
+	table.Append([]string{"Fruit", "Apple", "1.20"})
+	table.Append([]string{"Fruit", "Pear", "0.80"})
+	table.Append([]string{"Fruit", "Plum", "0.80"})
+	table.Append([]string{"Bread", "Rye", "2.50"})
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+	table.Render()
+
+	want := `+----------+-------+-------+
+| CATEGORY | ITEM  | PRICE |
++----------+-------+-------+
+| Fruit    | Apple |  1.20 |
+|          +-------+-------+
+|          | Pear  |  0.80 |
+|          +-------+       |
+|          | Plum  |       |
++----------+-------+-------+
+| Bread    | Rye   |  2.50 |
++----------+-------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("merged cell rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.SetFooter([]string{"", "Total", "788"})
+	table.SetFooterAutoNumericAlign(true)
+	if err := table.SelectColumns([]string{"Rating", "Name"}); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+--------+------+
+| RATING | NAME |
++--------+------+
+|    500 | A    |
+|    288 | B    |
++--------+------+
+|    788 |       
++--------+------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("selected columns rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	if err := table.SelectColumns([]string{"Sign"}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestHeaderAndColumnColor(t *testing.T) {
+	data := [][]string{
+		{"A", "The Good", "500"},
+		{"B", "The Bad", "288"},
+	}
+	var plain bytes.Buffer
+	table := NewWriter(&plain)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.AppendBulk(data)
+	table.Render()
+
+	var buf bytes.Buffer
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.AppendBulk(data)
+	table.SetHeaderColor(Colors{BOLD, FgGreenColor}, nil, Colors{BgBlueColor})
+	table.SetColumnColor(nil, Colors{FgRedColor})
+	table.Render()
+
+	got := buf.String()
+	for _, want := range []string{
+		"\033[1;32mNAME\033[0m",
+		"\033[44mRATING\033[0m",
+		"\033[31mThe Good\033[0m",
+		"\033[31mThe Bad \033[0m",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("colored output is missing %q\ngot:\n%s\n", want, got)
+		}
+	}
+	if stripped := regexp.MustCompile("\033\\[[0-9;]*m").ReplaceAllString(got, ""); stripped != plain.String() {
+		t.Errorf("colored rendering failed\ngot:\n%s\nwant:\n%s\n", stripped, plain.String())
+	}
+}
+
+func TestRowLineBottomBorder(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	table.Append([]string{"B", "288"})
+	table.SetFooter([]string{"Total", "788"})
+	table.SetRowLine(true)
+	table.SetFooterAutoNumericAlign(true)
+	table.SetBorders(Border{Left: true, Right: true, Top: true, Bottom: false})
+	table.Render()
+
+	want := `+-------+--------+
+| NAME  | RATING |
++-------+--------+
+| A     |    500 |
++-------+--------+
+| B     |    288 |
++-------+--------+
+  TOTAL |    788 |
++-------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("row line rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	table.Append([]string{"B", "288"})
+	table.SetRowLine(true)
+	table.SetMaxRows(1)
+	table.Render()
+
+	want = `+------+--------+
+| NAME | RATING |
++------+--------+
+| A    |    500 |
++------+--------+
+| … (1 more ro… |
++------+--------+
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("row line rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestMaxRowsNarrowTable(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.Append([]string{"A"})
+	table.Append([]string{"B"})
+	table.Append([]string{"C"})
+	table.SetMaxRows(1)
+	table.Render()
+
+	want := `+---+
+| A |
+| … |
++---+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("narrow truncation rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestEllipsisSide(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetAutoTruncate(true)
+	table.SetColWidth(10)
+	table.SetColumnAlignment([]int{ALIGN_LEFT, ALIGN_RIGHT})
+	table.SetEllipsisSide(1, ELLIPSIS_START)
+	table.Append([]string{"The quick brown fox", "/var/log/nginx/access.log"})
+	table.Render()
+
+	want := `+------------+------------+
+| The quick… | …ccess.log |
++------------+------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("ellipsis side rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRowColors(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetRowColors(Colors{BgBlackColor}, Colors{BgBlueColor})
+	table.Append([]string{"AB", "500"})
+	table.Append([]string{"CD", "288\n12"})
+	table.Append([]string{"EF", "7"})
+	table.Render()
+
+	want := "+----+-------+\n" +
+		"| \033[40mAB\033[0m | \033[40m  500\033[0m |\n" +
+		"| \033[44mCD\033[0m | \033[44m  288\033[0m |\n" +
+		"| \033[44m  \033[0m | \033[44m   12\033[0m |\n" +
+		"| \033[40mEF\033[0m | \033[40m    7\033[0m |\n" +
+		"+----+-------+\n"
+	got := buf.String()
+	if got != want {
+		t.Errorf("striped rendering failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+}
+
+func TestVerticalAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Address"})
+	table.AppendMultiline([][]string{{"Ada"}, {"12 Main St", "Springfield", "USA"}})
+	table.SetVerticalAlignment(VALIGN_MIDDLE)
+	table.Render()
+
+	want := `+------+-------------+
+| NAME |   ADDRESS   |
++------+-------------+
+|      | 12 Main St  |
+| Ada  | Springfield |
+|      | USA         |
++------+-------------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("vertical alignment rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestBoxTitle(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.SetStyle(StyleUnicode)
+	table.SetBoxTitle("Report")
+	table.Render()
+
+	want := `┌──────┬── Report ┬────────┐
+│ NAME │   SIGN   │ RATING │
+├──────┼──────────┼────────┤
+│ A    │ The Good │    500 │
+└──────┴──────────┴────────┘
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("box title rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table = NewWriter(&buf)
+	table.Append([]string{"A", "B"})
+	table.SetBoxTitle("Quarterly Report")
+	table.Render()
+
+	want = `+ Quar… +
+| A | B |
++---+---+
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("box title rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestCategoryColors(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetCategoryColors(1, map[string][]int{
+		"OK":   {BgGreenColor},
+		"FAIL": {BgRedColor},
+	})
+	table.Append([]string{"db", "OK"})
+	table.Append([]string{"web", "FAIL"})
+	table.Append([]string{"cache", "SKIP"})
+	table.Render()
+
+	want := "+-------+------+\n" +
+		"| \033[42mdb   \033[0m | \033[42mOK  \033[0m |\n" +
+		"| \033[41mweb  \033[0m | \033[41mFAIL\033[0m |\n" +
+		"| cache | SKIP |\n" +
+		"+-------+------+\n"
+	got := buf.String()
+	if got != want {
+		t.Errorf("category color rendering failed\ngot:\n%q\nwant:\n%q\n", got, want)
+	}
+}
+
+func TestColumnAlignOn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Task", "Time"})
+	table.Append([]string{"build", "1:30"})
+	table.Append([]string{"test", "10:05"})
+	table.Append([]string{"deploy", "100:00"})
+	table.Append([]string{"soak", "7:45:10"})
+	table.SetColumnAlignOn(1, ':')
+	table.Render()
+
+	want := `+--------+-----------+
+|  TASK  |   TIME    |
++--------+-----------+
+| build  |   1:30    |
+| test   |  10:05    |
+| deploy | 100:00    |
+| soak   |   7:45:10 |
++--------+-----------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("align on rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestHideColumn(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Sign", "Rating"})
+	table.Append([]string{"A", "The Good", "500"})
+	table.Append([]string{"B", "The Very very Bad Man", "288"})
+	table.SetFooter([]string{"", "Total", "788"})
+	table.HideColumn(1)
+	table.SetFooterAutoNumericAlign(true)
+	table.Render()
+
+	want := `+------+--------+
+| NAME | RATING |
++------+--------+
+| A    |    500 |
+| B    |    288 |
++------+--------+
+|           788 |
++------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("hidden column rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	buf.Reset()
+	table.ShowColumn(1)
+	table.HideColumn(0)
+	table.HideColumn(2)
+	table.Render()
+
+	want = `+-----------------------+
+|         SIGN          |
++-----------------------+
+| The Good              |
+| The Very very Bad Man |
++-----------------------+
+|         TOTAL         |
++-----------------------+
+`
+	got = buf.String()
+	if got != want {
+		t.Errorf("hidden column rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestHideColumnExports(t *testing.T) {
+	table := NewWriter(&bytes.Buffer{})
+	table.SetHeader([]string{"a", "b", "c"})
+	table.Append([]string{"1", "x", "3"})
+	table.HideColumn(0)
+
+	var markdown, html bytes.Buffer
+	if err := table.RenderMarkdown(&markdown); err != nil {
+		t.Fatal(err)
+	}
+	want := `| B   |   C |
+| --- | --: |
+| x   |   3 |
+`
+	if got := markdown.String(); got != want {
+		t.Errorf("markdown rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+
+	if err := table.RenderHTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	want = `<table>
+<thead>
+<tr><th>B</th><th>C</th></tr>
+</thead>
+<tbody>
+<tr><td>x</td><td style="text-align:right">3</td></tr>
+</tbody>
+</table>
+`
+	if got := html.String(); got != want {
+		t.Errorf("html rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+func TestRenderLines(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name", "Rating"})
+	table.Append([]string{"A", "500"})
+	table.Append([]string{"B", "288"})
+	table.SetFooter([]string{"Total", "788"})
+	table.SetRowLine(true)
+	table.SetCaption(true, "Ratings")
+
+	var kinds []LineKind
+	var text []string
+	for _, line := range table.RenderLines() {
+		kinds = append(kinds, line.Kind)
+		text = append(text, line.Text)
+	}
+	want := []LineKind{
+		LineTopRule, LineHeader, LineHeaderRule,
+		LineRow, LineRowRule, LineRow,
+		LineFooterRule, LineFooter, LineBottomRule,
+		LineCaption,
+	}
+	if fmt.Sprint(kinds) != fmt.Sprint(want) {
+		t.Errorf("line kinds failed\ngot:\n%v\nwant:\n%v\n", kinds, want)
+	}
+
+	table.Render()
+	if got := strings.Join(text, "\n") + "\n"; got != buf.String() {
+		t.Errorf("line rendering failed\ngot:\n%s\nwant:\n%s\n", got, buf.String())
+	}
+}
+
+func TestRenderLinesCaptionCRLF(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"Name"})
+	table.Append([]string{"A"})
+	table.SetCaption(true, "Names")
+	table.SetNewLine("\r\n")
+
+	lines := table.RenderLines()
+	last := lines[len(lines)-1]
+	if last.Kind != LineCaption || last.Text != "Names" {
+		t.Errorf("caption line Wants: %q Got: %v %q", "Names", last.Kind, last.Text)
+	}
+
+	table.Render()
+	if strings.Count(buf.String(), "\n") != strings.Count(buf.String(), "\r\n") {
+		t.Errorf("mixed line endings:\n%q", buf.String())
+	}
+}
+
+func TestFooterAutoFormat(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	table.SetHeader([]string{"item_name", "Amount"})
+	table.Append([]string{"Tea", "4.50"})
+	table.SetFooter([]string{"Grand total (USD)", "4.50"})
+	table.SetFooterAutoFormat(false)
+	table.SetFooterAutoNumericAlign(true)
+	table.Render()
+
+	want := `+-------------------+--------+
+|     ITEM NAME     | AMOUNT |
++-------------------+--------+
+| Tea               |   4.50 |
++-------------------+--------+
+| Grand total (USD) |   4.50 |
++-------------------+--------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("footer auto format rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
+
+// rowsDriver serves a fixed query result for TestAppendRows
+type rowsDriver struct{}
+
+func (rowsDriver) Open(name string) (driver.Conn, error) { return rowsConn{}, nil }
+
+type rowsConn struct{}
+
+func (rowsConn) Prepare(query string) (driver.Stmt, error) { return rowsStmt{}, nil }
+func (rowsConn) Close() error                              { return nil }
+func (rowsConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type rowsStmt struct{}
+
+func (rowsStmt) Close() error                                    { return nil }
+func (rowsStmt) NumInput() int                                   { return -1 }
+func (rowsStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (rowsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &rowsResult{data: [][]driver.Value{
+		{int64(1), []byte("Ann"), 12.5},
+		{int64(2), nil, 7.0},
+	}}, nil
+}
+
+type rowsResult struct {
+	data [][]driver.Value
+}
+
+func (r *rowsResult) Columns() []string { return []string{"id", "name", "score"} }
+func (r *rowsResult) Close() error      { return nil }
+func (r *rowsResult) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.data[0])
+	r.data = r.data[1:]
+	return nil
+}
+
+func TestAppendRows(t *testing.T) {
+	sql.Register("tablewriter-rows", rowsDriver{})
+	db, err := sql.Open("tablewriter-rows", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT id, name, score FROM scores")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var buf bytes.Buffer
+	table := NewWriter(&buf)
+	if err := table.AppendRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	table.Render()
+
+	want := `+----+------+-------+
+| ID | NAME | SCORE |
++----+------+-------+
+|  1 | Ann  |  12.5 |
+|  2 |      |     7 |
++----+------+-------+
+`
+	got := buf.String()
+	if got != want {
+		t.Errorf("sql rows rendering failed\ngot:\n%s\nwant:\n%s\n", got, want)
+	}
+}
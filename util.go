@@ -8,19 +8,177 @@
 package tablewriter
 
 import (
-	"math"
+	"bytes"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/mattn/go-runewidth"
 )
 
-var ansi = regexp.MustCompile("\033\\[(?:[0-9]{1,3}(?:;[0-9]{1,3})*)?[m|K]")
+var ansi = regexp.MustCompile("\033\\[(?:[0-9]{1,3}(?:;[0-9]{1,3})*)?[mK]")
 
+// Return the number of terminal columns a string occupies
+// ANSI escape sequences are ignored and wide characters count as two columns
 func DisplayWidth(str string) int {
 	return runewidth.StringWidth(ansi.ReplaceAllLiteralString(str, ""))
 }
 
+// Spread a line over width cells, padding with spaces
+// Wide characters are followed by a zero rune for their second cell
+func gridRow(s string, width int) []rune {
+	row := make([]rune, 0, width)
+	for _, r := range s {
+		row = append(row, r)
+		for i := 1; i < runewidth.RuneWidth(r); i++ {
+			row = append(row, 0)
+		}
+	}
+	for len(row) < width {
+		row = append(row, ' ')
+	}
+	return row
+}
+
+// Truncate String
+// Cut a string to fit width columns and end it with the ellipsis
+// ANSI escape sequences are kept intact and do not count toward the width
+func Truncate(s string, width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	limit := width - DisplayWidth(ellipsis)
+	if limit < 0 {
+		return Truncate(ellipsis, width, "")
+	}
+
+	var (
+		buf bytes.Buffer
+		w   int
+		i   int
+	)
+	for i < len(s) {
+		if loc := ansi.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			buf.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := runewidth.RuneWidth(r)
+		if w+rw > limit {
+			break
+		}
+		buf.WriteRune(r)
+		w += rw
+		i += size
+	}
+	buf.WriteString(ellipsis)
+
+	// Keep trailing escape sequences so colors are still reset
+	for _, code := range ansi.FindAllString(s[i:], -1) {
+		buf.WriteString(code)
+	}
+	return buf.String()
+}
+
+// Cut a string to fit width columns, dropping text from the start
+// The ellipsis leads the kept text and ANSI escape sequences stay intact
+func truncateStart(s string, width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	limit := width - DisplayWidth(ellipsis)
+	if limit < 0 {
+		return Truncate(ellipsis, width, "")
+	}
+
+	// Split into escape sequences and single runes
+	tokens := []string{}
+	for i := 0; i < len(s); {
+		size := 0
+		if loc := ansi.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			size = loc[1]
+		} else {
+			_, size = utf8.DecodeRuneInString(s[i:])
+		}
+		tokens = append(tokens, s[i:i+size])
+		i += size
+	}
+
+	start, w := len(tokens), 0
+	for ; start > 0; start-- {
+		tok := tokens[start-1]
+		if ansi.MatchString(tok) {
+			continue
+		}
+		if w+DisplayWidth(tok) > limit {
+			break
+		}
+		w += DisplayWidth(tok)
+	}
+
+	// Keep leading escape sequences so colors still apply
+	var buf bytes.Buffer
+	for _, tok := range tokens[:start] {
+		if ansi.MatchString(tok) {
+			buf.WriteString(tok)
+		}
+	}
+	buf.WriteString(ellipsis)
+	buf.WriteString(strings.Join(tokens[start:], ""))
+	return buf.String()
+}
+
+// Format String
+// Wrap a string in an SGR escape sequence and a reset
+func format(s string, codes []int) string {
+	if len(codes) == 0 {
+		return s
+	}
+	seq := make([]string, len(codes))
+	for i, c := range codes {
+		seq[i] = strconv.Itoa(c)
+	}
+	return "\033[" + strings.Join(seq, ";") + "m" + s + "\033[0m"
+}
+
+// Format a negative number accounting style
+// The minus sign is replaced by parentheses and digits are grouped
+// by thousands. Any other value is returned unchanged
+func Accounting(s string) string {
+	v := strings.TrimSpace(s)
+	if !negative.MatchString(v) {
+		return s
+	}
+	return "(" + GroupDigits(strings.Replace(v[1:], ",", "", -1)) + ")"
+}
+
+// Group the integer digits of a number by thousands
+func GroupDigits(s string) string {
+	start := strings.IndexAny(s, "0123456789")
+	if start < 0 {
+		return s
+	}
+	end := strings.Index(s, ".")
+	if end < 0 {
+		end = len(s)
+	}
+	digits := s[start:end]
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return s[:start] + digits + s[end:]
+}
+
 // Simple Condition for string
 // Returns value based on condition
 func ConditionString(cond bool, valid, inValid string) string {
@@ -33,18 +191,61 @@ func ConditionString(cond bool, valid, inValid string) string {
 // Format Table Header
 // Replace _ , . and spaces
 func Title(name string) string {
+	return mapText(Normalize(name), strings.ToUpper)
+}
+
+// Apply f to the text of s, leaving ANSI escape sequences untouched
+func mapText(s string, f func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range ansi.FindAllStringIndex(s, -1) {
+		b.WriteString(f(s[last:loc[0]]))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(f(s[last:]))
+	return b.String()
+}
+
+// Normalize Table Header
+// Replace _ , . and spaces without changing case
+func Normalize(name string) string {
 	name = strings.Replace(name, "_", " ", -1)
 	name = strings.Replace(name, ".", " ", -1)
-	name = strings.TrimSpace(name)
-	return strings.ToUpper(name)
+	return strings.TrimSpace(name)
+}
+
+// Zero Pad String
+// Pad an integer with leading zeros to width characters
+// Anything that is not an integer is returned unchanged
+func ZeroPad(s string, width int) string {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return s
+	}
+	return fmt.Sprintf("%0*d", width, n)
+}
+
+// Title Case String
+// Upper case the first letter of every word and lower case the rest
+func TitleCase(name string) string {
+	words := strings.Split(strings.ToLower(name), SPACE)
+	for i, w := range words {
+		if w != "" {
+			r, size := utf8.DecodeRuneInString(w)
+			words[i] = string(unicode.ToUpper(r)) + w[size:]
+		}
+	}
+	return strings.Join(words, SPACE)
 }
 
 // Pad String
 // Attempts to play string in the center
+// Gaps are measured in display width so wide and colored text line up
 func Pad(s, pad string, width int) string {
 	gap := width - DisplayWidth(s)
 	if gap > 0 {
-		gapLeft := int(math.Ceil(float64(gap / 2)))
+		gapLeft := gap / 2
 		gapRight := gap - gapLeft
 		return strings.Repeat(string(pad), gapLeft) + s + strings.Repeat(string(pad), gapRight)
 	}
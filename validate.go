@@ -0,0 +1,132 @@
+// Copyright 2014 Oleku Konko All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// This module is a Table Writer  API for the Go Programming Language.
+// The protocols were written in pure Go and works on windows and unix systems
+
+package tablewriter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Validate table configuration
+// Returns a descriptive error for the first inconsistent setting found,
+// so command line tools can fail fast before rendering
+func (t *Table) Validate() error {
+	aligns := []struct {
+		name  string
+		align int
+	}{
+		{"alignment", t.align},
+		{"header alignment", t.hAlign},
+		{"footer alignment", t.fAlign},
+		{"caption alignment", t.captionAlign},
+		{"footer aggregate alignment", t.aggAlign},
+	}
+	for _, a := range aligns {
+		if a.align < ALIGN_DEFAULT || a.align > ALIGN_LEFT {
+			return fmt.Errorf("tablewriter: invalid %s %d", a.name, a.align)
+		}
+	}
+	for col, align := range t.colAlign {
+		if align < ALIGN_DEFAULT || align > ALIGN_LEFT {
+			return fmt.Errorf("tablewriter: invalid alignment %d for column %d", align, col)
+		}
+	}
+	if t.vAlign < VALIGN_TOP || t.vAlign > VALIGN_BOTTOM {
+		return fmt.Errorf("tablewriter: invalid vertical alignment %d", t.vAlign)
+	}
+	for _, col := range sortedKeys(t.ellSides) {
+		if side := t.ellSides[col]; side < ELLIPSIS_END || side > ELLIPSIS_START {
+			return fmt.Errorf("tablewriter: invalid ellipsis side %d for column %d", side, col)
+		}
+	}
+	if t.hCase < CASE_UPPER || t.hCase > CASE_TITLE {
+		return fmt.Errorf("tablewriter: invalid header case %d", t.hCase)
+	}
+	if t.fRule < FOOTER_RULE_MINIMAL || t.fRule > FOOTER_RULE_NONE {
+		return fmt.Errorf("tablewriter: invalid footer rule mode %d", t.fRule)
+	}
+	if t.exportNL < EXPORT_NEWLINE_DEFAULT || t.exportNL > EXPORT_NEWLINE_SPACE {
+		return fmt.Errorf("tablewriter: invalid export newline mode %d", t.exportNL)
+	}
+	if t.maxCols < 0 {
+		return fmt.Errorf("tablewriter: negative maximum columns %d", t.maxCols)
+	}
+	if t.maxRows < 0 {
+		return fmt.Errorf("tablewriter: negative maximum rows %d", t.maxRows)
+	}
+	if t.maxHeight < 0 {
+		return fmt.Errorf("tablewriter: negative maximum row height %d", t.maxHeight)
+	}
+	if t.hdrGap < 0 {
+		return fmt.Errorf("tablewriter: negative header gap %d", t.hdrGap)
+	}
+	if t.captionGap < 0 {
+		return fmt.Errorf("tablewriter: negative caption gap %d", t.captionGap)
+	}
+
+	// Separators may only hold complete ANSI sequences, any other
+	// control character would throw off the width of every line
+	seps := []struct {
+		name string
+		sep  string
+	}{
+		{"column separator", t.pColumn},
+		{"row separator", t.pRow},
+		{"center separator", t.pCenter},
+		{"footer junction", t.fJunction},
+	}
+	for _, s := range seps {
+		if strings.IndexFunc(ansi.ReplaceAllLiteralString(s.sep, ""), unicode.IsControl) >= 0 {
+			return fmt.Errorf("tablewriter: malformed escape sequence in %s %q", s.name, s.sep)
+		}
+	}
+
+	for _, col := range sortedKeys(t.colMin) {
+		min := t.colMin[col]
+		if max, ok := t.colMax[col]; ok && max > 0 && min > max {
+			return fmt.Errorf("tablewriter: minimum width %d exceeds maximum width %d for column %d", min, max, col)
+		}
+	}
+
+	// Column settings must refer to existing columns
+	cols := len(t.cs)
+	if t.sortCol >= cols {
+		return fmt.Errorf("tablewriter: sort column %d out of range for %d columns", t.sortCol, cols)
+	}
+	if t.captionAnchor >= cols {
+		return fmt.Errorf("tablewriter: caption anchor %d out of range for %d columns", t.captionAnchor, cols)
+	}
+	if len(t.colAlign) > cols {
+		return fmt.Errorf("tablewriter: column alignment set for %d columns, table has %d", len(t.colAlign), cols)
+	}
+	for _, col := range sortedKeys(t.colTypes) {
+		if col < 0 || col >= cols {
+			return fmt.Errorf("tablewriter: column type set for column %d out of range for %d columns", col, cols)
+		}
+	}
+	for _, col := range sortedKeys(t.accounting) {
+		if col < 0 || col >= cols {
+			return fmt.Errorf("tablewriter: accounting set for column %d out of range for %d columns", col, cols)
+		}
+	}
+	return nil
+}
+
+// Return the column keys of a map in ascending order
+// Settings are checked in column order so the reported error is stable
+func sortedKeys(m interface{}) []int {
+	keys := []int{}
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, int(k.Int()))
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -8,6 +8,7 @@
 package tablewriter
 
 import (
+	"bytes"
 	"math"
 	"strings"
 	"unicode/utf8"
@@ -20,14 +21,27 @@ var (
 
 const defaultPenalty = 1e5
 
-// Wrap wraps s into a paragraph of lines of length lim, with minimal
-// raggedness.
+// WrapString wraps s into a paragraph of lines of length lim, with minimal
+// raggedness. It returns the lines and the width actually used, which is
+// lim widened to the length of the longest word when a word does not fit.
 func WrapString(s string, lim int) ([]string, int) {
+	return wrapString(s, lim, false)
+}
+
+// wrapString is WrapString with optional smart wrapping, which attaches
+// tokens made only of closing punctuation to the preceding word so they
+// never start a line on their own, and breaks long URLs after a slash or
+// question mark instead of widening the line.
+func wrapString(s string, lim int, smart bool) ([]string, int) {
 	words := strings.Split(strings.Replace(strings.TrimSpace(s), nl, sp, -1), sp)
+	if smart {
+		words = attachPunctuation(words)
+		words = splitURLs(words, lim)
+	}
 	var lines []string
 	max := 0
 	for _, v := range words {
-		max = len(v)
+		max = utf8.RuneCountInString(v)
 		if max > lim {
 			lim = max
 		}
@@ -38,6 +52,55 @@ func WrapString(s string, lim int) ([]string, int) {
 	return lines, lim
 }
 
+// attachPunctuation joins punctuation-only words to the word before them.
+func attachPunctuation(words []string) []string {
+	var out []string
+	for _, w := range words {
+		if len(out) > 0 && w != "" && strings.Trim(w, ".,;:!?)]}") == "" {
+			out[len(out)-1] += sp + w
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// splitURLs breaks URLs longer than lim into pieces of at most lim runes,
+// ending each piece after a '/' or '?' and never inside the scheme.
+// A piece with no break point that fits stays whole.
+func splitURLs(words []string, lim int) []string {
+	var out []string
+	for _, w := range words {
+		start := strings.Index(w, "://")
+		if start < 0 || utf8.RuneCountInString(w) <= lim {
+			out = append(out, w)
+			continue
+		}
+		// Break points follow the host, after the scheme separator
+		var segments []string
+		last := 0
+		for i := start + 3; i < len(w); i++ {
+			if w[i] == '/' || w[i] == '?' {
+				segments = append(segments, w[last:i+1])
+				last = i + 1
+			}
+		}
+		if last < len(w) {
+			segments = append(segments, w[last:])
+		}
+		piece := ""
+		for _, seg := range segments {
+			if piece != "" && utf8.RuneCountInString(piece+seg) > lim {
+				out = append(out, piece)
+				piece = ""
+			}
+			piece += seg
+		}
+		out = append(out, piece)
+	}
+	return out
+}
+
 // WrapWords is the low-level line-breaking algorithm, useful if you need more
 // control over the details of the text wrapping process. For most uses,
 // WrapString will be sufficient and more convenient.
@@ -92,6 +155,44 @@ func WrapWords(words []string, spc, lim, pen int) [][]string {
 	return lines
 }
 
+// wrapStream greedily wraps s into lines of at most lim columns and passes
+// each line to fn as soon as it is complete, so the wrapped text is never
+// held in memory at once.
+func wrapStream(s string, lim int, fn func(string)) {
+	var (
+		line  bytes.Buffer
+		width int
+	)
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			break
+		}
+		end := strings.IndexAny(s, " \t\r\n")
+		if end < 0 {
+			end = len(s)
+		}
+		word := s[:end]
+		s = s[end:]
+
+		w := DisplayWidth(word)
+		if width > 0 && width+len(sp)+w > lim {
+			fn(line.String())
+			line.Reset()
+			width = 0
+		}
+		if width > 0 {
+			line.WriteString(sp)
+			width += len(sp)
+		}
+		line.WriteString(word)
+		width += w
+	}
+	if width > 0 {
+		fn(line.String())
+	}
+}
+
 // getLines decomposes a multiline string into a slice of strings.
 func getLines(s string) []string {
 	var lines []string
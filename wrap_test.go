@@ -8,8 +8,10 @@
 package tablewriter
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 var text = "The quick brown fox jumps over the lazy dog."
@@ -34,6 +36,29 @@ func TestWrapOneLine(t *testing.T) {
 	}
 }
 
+func TestWrapWidth(t *testing.T) {
+	exp := []string{"The quick brown", "fox jumps over", "the lazy dog."}
+	got, width := WrapString(text, 15)
+	if strings.Join(got, "|") != strings.Join(exp, "|") {
+		t.Errorf("Wants: %q Got: %q", exp, got)
+	}
+	if width != 15 {
+		t.Errorf("Wants: %d Got: %d", 15, width)
+	}
+}
+
+func TestWrapLongWord(t *testing.T) {
+	got, width := WrapString("a supercalifragilistic word", 6)
+	exp := []string{"a", "supercalifragilistic", "word"}
+	if strings.Join(got, "|") != strings.Join(exp, "|") {
+		t.Errorf("Wants: %q Got: %q", exp, got)
+	}
+	// The width grows to fit the longest word
+	if width != 20 {
+		t.Errorf("Wants: %d Got: %d", 20, width)
+	}
+}
+
 func TestUnicode(t *testing.T) {
 	input := "Česká řeřicha"
 	wordsUnicode, _ := WrapString(input, 13)
@@ -44,7 +69,19 @@ func TestUnicode(t *testing.T) {
 }
 
 func TestDisplayWidth(t *testing.T) {
-	input := "Česká řeřicha"
+	input := "hello"
+	if n := DisplayWidth(input); n != 5 {
+		t.Errorf("Wants: %d Got: %d", 5, n)
+	}
+	input = "\033[1;32m" + input + "\033[0m"
+	if n := DisplayWidth(input); n != 5 {
+		t.Errorf("Wants: %d Got: %d", 5, n)
+	}
+	input = "日本語"
+	if n := DisplayWidth(input); n != 6 {
+		t.Errorf("Wants: %d Got: %d", 6, n)
+	}
+	input = "Česká řeřicha"
 	if n := DisplayWidth(input); n != 13 {
 		t.Errorf("Wants: %d Got: %d", 13, n)
 	}
@@ -53,3 +90,134 @@ func TestDisplayWidth(t *testing.T) {
 		t.Errorf("Wants: %d Got: %d", 13, n)
 	}
 }
+
+func TestTruncate(t *testing.T) {
+	if got := Truncate("The quick brown fox", 10, "..."); got != "The qui..." {
+		t.Errorf("Wants: %q Got: %q", "The qui...", got)
+	}
+	if got := Truncate("日本語テキスト", 7, "…"); DisplayWidth(got) > 7 || got != "日本語…" {
+		t.Errorf("Wants: %q Got: %q", "日本語…", got)
+	}
+	input := "\033[31mThe quick brown fox\033[0m"
+	want := "\033[31mThe qui...\033[0m"
+	if got := Truncate(input, 10, "..."); got != want {
+		t.Errorf("Wants: %q Got: %q", want, got)
+	}
+}
+
+func TestTruncateNoWidth(t *testing.T) {
+	for _, width := range []int{-1, 0} {
+		if got := Truncate("abc", width, "..."); got != "" {
+			t.Errorf("Truncate width %d Wants: %q Got: %q", width, "", got)
+		}
+		if got := truncateStart("abc", width, "..."); got != "" {
+			t.Errorf("truncateStart width %d Wants: %q Got: %q", width, "", got)
+		}
+	}
+}
+
+func TestTruncateStart(t *testing.T) {
+	if got := truncateStart("The quick brown fox", 10, "..."); got != "...own fox" {
+		t.Errorf("Wants: %q Got: %q", "...own fox", got)
+	}
+	input := "\033[31mThe quick brown fox\033[0m"
+	want := "\033[31m...own fox\033[0m"
+	if got := truncateStart(input, 10, "..."); got != want {
+		t.Errorf("Wants: %q Got: %q", want, got)
+	}
+}
+
+func TestAccounting(t *testing.T) {
+	tests := map[string]string{
+		"-1234.50":  "(1,234.50)",
+		"-1,234.50": "(1,234.50)",
+		"-$1234567": "($1,234,567)",
+		"1234.50":   "1234.50",
+		"-abc":      "-abc",
+	}
+	for input, want := range tests {
+		if got := Accounting(input); got != want {
+			t.Errorf("Accounting(%q) Wants: %q Got: %q", input, want, got)
+		}
+	}
+}
+
+func TestPadDisplayWidth(t *testing.T) {
+	wide := "日本"
+	if got, want := Pad(wide, " ", 8), "  日本  "; got != want {
+		t.Errorf("Pad Wants: %q Got: %q", want, got)
+	}
+	if got, want := PadRight(wide, " ", 8), "日本    "; got != want {
+		t.Errorf("PadRight Wants: %q Got: %q", want, got)
+	}
+	if got, want := PadLeft(wide, " ", 8), "    日本"; got != want {
+		t.Errorf("PadLeft Wants: %q Got: %q", want, got)
+	}
+
+	colored := "\033[31mab\033[0m"
+	if got, want := Pad(colored, " ", 5), " "+colored+"  "; got != want {
+		t.Errorf("Pad Wants: %q Got: %q", want, got)
+	}
+	if got, want := PadRight(colored, " ", 4), colored+"  "; got != want {
+		t.Errorf("PadRight Wants: %q Got: %q", want, got)
+	}
+	if got, want := PadLeft(colored, " ", 4), "  "+colored; got != want {
+		t.Errorf("PadLeft Wants: %q Got: %q", want, got)
+	}
+}
+
+func TestSmartWrapPunctuation(t *testing.T) {
+	text := "see the notes ( page 12 ) ."
+	lines, _ := wrapString(text, 7, false)
+	if got := lines[len(lines)-1]; got != ") ." {
+		t.Fatalf("expected plain wrapping to strand the parenthesis, got %q", lines)
+	}
+
+	lines, _ = wrapString(text, 7, true)
+	for _, line := range lines {
+		if strings.IndexAny(line[:1], ".,)") == 0 {
+			t.Errorf("line %q starts with punctuation in %q", line, lines)
+		}
+	}
+	if got, want := strings.Join(lines, " "), text; got != want {
+		t.Errorf("smart wrapping lost text: got %q want %q", got, want)
+	}
+}
+
+func TestZeroPad(t *testing.T) {
+	tests := map[string]string{
+		"7":     "0007",
+		"1234":  "1234",
+		"98765": "98765",
+		"-7":    "-007",
+		"7.5":   "7.5",
+		"n/a":   "n/a",
+	}
+	for input, want := range tests {
+		if got := ZeroPad(input, 4); got != want {
+			t.Errorf("ZeroPad(%q) Wants: %q Got: %q", input, want, got)
+		}
+	}
+}
+
+func TestSmartWrapURL(t *testing.T) {
+	url := "https://example.com/docs/reference/tables?page=2&sort=name"
+	lines, _ := wrapString("see "+url, 24, true)
+	want := []string{"see https://example.com/", "docs/reference/tables?", "page=2&sort=name"}
+	if fmt.Sprint(lines) != fmt.Sprint(want) {
+		t.Fatalf("wrapString Wants: %q Got: %q", want, lines)
+	}
+	for _, line := range lines {
+		if utf8.RuneCountInString(line) > 24 {
+			t.Errorf("line %q is wider than 24", line)
+		}
+	}
+	if !strings.Contains(lines[0], "https://") {
+		t.Errorf("scheme was split: %q", lines)
+	}
+
+	plain, _ := wrapString("see "+url, 24, false)
+	if plain[len(plain)-1] != url {
+		t.Errorf("plain wrapping split the URL: %q", plain)
+	}
+}